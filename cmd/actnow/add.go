@@ -0,0 +1,108 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mrbooshehri/actNow/internal/config"
+	"github.com/mrbooshehri/actNow/internal/dateparse"
+	"github.com/mrbooshehri/actNow/internal/engine"
+	"github.com/mrbooshehri/actNow/internal/model"
+	"github.com/mrbooshehri/actNow/internal/quickadd"
+	"github.com/mrbooshehri/actNow/internal/store"
+)
+
+func runAdd(st *store.Store, args []string) error {
+	fs := flag.NewFlagSet("add", flag.ContinueOnError)
+	important := fs.Bool("important", false, "mark the task important")
+	urgent := fs.Bool("urgent", false, "mark the task urgent")
+	due := fs.String("due", "", "due date, e.g. \"tomorrow 9:00\", fri, +3d, eod or 2024-03-08")
+	planned := fs.String("planned", "", "planned date, in the same forms as --due")
+	description := fs.String("description", "", "task description")
+	tags := fs.String("tags", "", "comma separated tags")
+	delegate := fs.String("delegate", "", "who the task is delegated to")
+	effort := fs.String("effort", "", "effort estimate, e.g. 2h, 1d or 3sp")
+	project := fs.String("project", "", "project name")
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	title := strings.TrimSpace(strings.Join(positional, " "))
+	if title == "" {
+		return fmt.Errorf("add: missing title")
+	}
+
+	// Without flags, the arguments are read in the quick-add syntax.
+	now := time.Now()
+	var task model.Task
+	if fs.NFlag() == 0 {
+		if task, err = quickadd.Parse(joinArgs(positional), now); err != nil {
+			return fmt.Errorf("add: %w", err)
+		}
+	} else {
+		dueAt, err := parseDateFlag("due", *due, now)
+		if err != nil {
+			return err
+		}
+		plannedAt, err := parseDateFlag("planned", *planned, now)
+		if err != nil {
+			return err
+		}
+		task = model.NewTask(title, strings.TrimSpace(*description), *important, *urgent, dueAt)
+		task.PlannedDate = plannedAt
+		task.Tags = model.ParseTags(*tags)
+		task.DelegateTo = strings.TrimSpace(*delegate)
+		task.EffortEstimate = strings.TrimSpace(*effort)
+		task.Project = strings.TrimSpace(*project)
+	}
+	if task.EffortEstimate != "" {
+		cfg, _ := config.Load(st.Dir())
+		if _, err := engine.ParseEffort(task.EffortEstimate, cfg.Points()); err != nil {
+			return fmt.Errorf("add: effort: %w", err)
+		}
+	}
+
+	tasks, err := loadTasksStrict(st)
+	if err != nil {
+		return err
+	}
+	task.Order = engine.NextOrder(tasks)
+	task = engine.ApplyUrgency(task, now)
+	if err := saveTasks(st, append(tasks, task)); err != nil {
+		return err
+	}
+	fmt.Printf("%s  [%s]\n", taskLine(task), engine.Quadrant(task))
+	fmt.Println("  " + quickadd.Format(task))
+	return nil
+}
+
+// joinArgs re-quotes marker values with spaces, e.g. @due:"tomorrow 9:00".
+func joinArgs(args []string) string {
+	out := make([]string, len(args))
+	for i, arg := range args {
+		out[i] = arg
+		if !strings.ContainsAny(arg, " \t") || strings.Contains(arg, `"`) {
+			continue
+		}
+		if key, value, ok := strings.Cut(arg, ":"); ok && strings.HasPrefix(arg, "@") && !strings.ContainsAny(key, " \t") {
+			out[i] = key + `:"` + value + `"`
+		} else if strings.ContainsRune(">~", rune(arg[0])) {
+			out[i] = arg[:1] + `"` + arg[1:] + `"`
+		}
+	}
+	return strings.Join(out, " ")
+}
+
+// parseDateFlag reads an optional date flag with dateparse.
+func parseDateFlag(name, value string, now time.Time) (*time.Time, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+	t, err := dateparse.Parse(value, now)
+	if err != nil {
+		return nil, fmt.Errorf("add: --%s: %w", name, err)
+	}
+	return &t, nil
+}
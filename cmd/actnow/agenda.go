@@ -0,0 +1,50 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/mrbooshehri/actNow/internal/engine"
+	"github.com/mrbooshehri/actNow/internal/format"
+	"github.com/mrbooshehri/actNow/internal/model"
+	"github.com/mrbooshehri/actNow/internal/store"
+)
+
+func runAgenda(st *store.Store, args []string) error {
+	fs := flag.NewFlagSet("agenda", flag.ContinueOnError)
+	days := fs.Int("days", 7, "how many days ahead to show, from today (0 for all)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *days < 0 {
+		return fmt.Errorf("agenda: --days must not be negative")
+	}
+	tasks, _, err := loadTasks(st)
+	if err != nil {
+		return err
+	}
+	groups := engine.Agenda(tasks, time.Now(), *days)
+	if len(groups) == 0 {
+		fmt.Println("nothing due or planned")
+		return nil
+	}
+	printAgenda(os.Stdout, tasks, groups)
+	return nil
+}
+
+func printAgenda(w io.Writer, tasks []model.Task, groups []engine.AgendaGroup) {
+	for i, g := range groups {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "%s (%d)\n", g.Name, len(g.Items))
+		for _, it := range g.Items {
+			t := tasks[it.Index]
+			fmt.Fprintf(w, "  %s %-16s %-7s  %s  [%s]\n", format.QuadrantMarker(engine.QuadrantIndex(t)), format.AgendaTime(g.Name, it.At), it.Kind,
+				taskLine(t), engine.QuadrantKeys[engine.QuadrantIndex(t)])
+		}
+	}
+}
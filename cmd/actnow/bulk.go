@@ -0,0 +1,198 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mrbooshehri/actNow/internal/engine"
+	"github.com/mrbooshehri/actNow/internal/format"
+	"github.com/mrbooshehri/actNow/internal/model"
+	"github.com/mrbooshehri/actNow/internal/query"
+	"github.com/mrbooshehri/actNow/internal/store"
+)
+
+// bulkOp is a change applied to each selected task; an apply error skips it.
+type bulkOp struct {
+	value    string
+	verb     string
+	validate func(value string) error
+	apply    func(t *model.Task, value string, now time.Time) error
+}
+
+var bulkOps = map[string]bulkOp{
+	"done": {verb: "marked done", apply: func(t *model.Task, _ string, _ time.Time) error {
+		t.Status = model.StatusDone
+		return nil
+	}},
+	"defer": {verb: "deferred", apply: func(t *model.Task, _ string, _ time.Time) error {
+		t.Status = model.StatusDeferred
+		return nil
+	}},
+	"tag": {value: "tags", verb: "re-tagged", apply: func(t *model.Task, value string, _ time.Time) error {
+		t.Tags = model.EditTags(t.Tags, value)
+		return nil
+	}},
+	"delegate": {value: "name", verb: "delegated", apply: func(t *model.Task, value string, _ time.Time) error {
+		t.DelegateTo = value
+		return nil
+	}},
+	"move": {value: "quadrant", verb: "moved", validate: func(value string) error {
+		if _, ok := query.QuadrantAliases[strings.ToLower(value)]; !ok {
+			return fmt.Errorf("unknown quadrant %q (want iim, inim, niim, nini or 1-4)", value)
+		}
+		return nil
+	}, apply: func(t *model.Task, value string, now time.Time) error {
+		moved := engine.SetQuadrant(*t, query.QuadrantAliases[strings.ToLower(value)])
+		if !moved.Urgent && engine.ApplyUrgency(moved, now).Urgent {
+			return fmt.Errorf("due within 24h, stays urgent")
+		}
+		*t = moved
+		return nil
+	}},
+}
+
+func runBulk(st *store.Store, name string, args []string) error {
+	op, isOp := bulkOps[name]
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	filter := fs.String("filter", "", "apply to every task matching the filter expression")
+	var reason string
+	if !isOp {
+		fs.StringVar(&reason, "reason", "", "reason recorded in the trash, instead of each task's delete reason")
+	}
+	// The value goes first so that "-tag" is not taken for a flag. Tag
+	// edits may span several arguments: "tag +ops -old ABC".
+	value := ""
+	if op.value != "" && len(args) > 0 && !strings.HasPrefix(args[0], "--") {
+		value, args = args[0], args[1:]
+		for name == "tag" && len(args) > 0 && isTagEdit(args[0]) {
+			value, args = value+" "+args[0], args[1:]
+		}
+	}
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if op.value != "" && value == "" {
+		if len(positional) == 0 {
+			return fmt.Errorf("%s: missing %s", name, op.value)
+		}
+		value, positional = positional[0], positional[1:]
+	}
+	if op.validate != nil {
+		if err := op.validate(value); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	if len(positional) == 0 && *filter == "" {
+		return fmt.Errorf("%s: pass task ids or --filter", name)
+	}
+
+	tasks, err := loadTasksStrict(st)
+	if err != nil {
+		return err
+	}
+	targets, err := selectTasks(tasks, positional, *filter)
+	if err != nil {
+		return err
+	}
+	if len(targets) == 0 {
+		fmt.Println("no matching tasks")
+		return nil
+	}
+
+	now := time.Now()
+	if !isOp {
+		return deleteTasks(st, tasks, targets, reason, now)
+	}
+	changed := 0
+	for _, idx := range targets {
+		before := tasks[idx]
+		if err := op.apply(&tasks[idx], value, now); err != nil {
+			fmt.Printf("skipped %s: %v\n", taskLine(before), err)
+			continue
+		}
+		tasks[idx].RecordChanges(before, now)
+		fmt.Println(taskLine(tasks[idx]))
+		changed++
+	}
+	if err := saveTasks(st, tasks); err != nil {
+		return err
+	}
+	fmt.Printf("%s %s\n", format.Plural(changed, "task", "tasks"), op.verb)
+	return nil
+}
+
+// deleteTasks falls back to each task's delete reason when reason is empty.
+func deleteTasks(st *store.Store, tasks []model.Task, indices []int, reason string, now time.Time) error {
+	drop := map[int]bool{}
+	entries := make([]model.TrashEntry, 0, len(indices))
+	for _, idx := range indices {
+		drop[idx] = true
+		why := reason
+		if why == "" {
+			why = tasks[idx].DeleteReason
+		}
+		entries = append(entries, model.TrashEntry{Task: tasks[idx], DeletedAt: now, Reason: why})
+		fmt.Println(taskLine(tasks[idx]))
+	}
+	if err := appendTrash(st, entries); err != nil {
+		return err
+	}
+	kept := tasks[:0]
+	for i, t := range tasks {
+		if !drop[i] {
+			kept = append(kept, t)
+		}
+	}
+	if err := saveTasks(st, kept); err != nil {
+		return err
+	}
+	fmt.Printf("%s moved to trash\n", format.Plural(len(entries), "task", "tasks"))
+	return nil
+}
+
+// selectTasks returns the tasks listed by ID prefix and matching filter.
+func selectTasks(tasks []model.Task, ids []string, filter string) ([]int, error) {
+	q, err := query.Parse(filter)
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter: %w", err)
+	}
+	now := time.Now()
+	if len(ids) == 0 {
+		return q.Filter(tasks, now), nil
+	}
+	var out []int
+	seen := map[int]bool{}
+	for _, id := range ids {
+		idx, err := findTask(tasks, id)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[idx] && q.Match(tasks[idx], now) {
+			seen[idx] = true
+			out = append(out, idx)
+		}
+	}
+	return out, nil
+}
+
+// parseInterspersed allows flags after positional arguments.
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		if fs.NArg() == 0 {
+			return positional, nil
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+}
+
+func isTagEdit(arg string) bool {
+	return strings.HasPrefix(arg, "+") || (strings.HasPrefix(arg, "-") && !strings.HasPrefix(arg, "--"))
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mrbooshehri/actNow/internal/engine"
+	"github.com/mrbooshehri/actNow/internal/model"
+	"github.com/mrbooshehri/actNow/internal/store"
+)
+
+func runDelegated(st *store.Store, args []string) error {
+	fs := flag.NewFlagSet("delegated", flag.ContinueOnError)
+	to := fs.String("to", "", "only list tasks delegated to this person")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	tasks, _, err := loadTasks(st)
+	if err != nil {
+		return err
+	}
+	groups := engine.WaitingFor(tasks, *to)
+	if len(groups) == 0 {
+		if *to != "" {
+			fmt.Printf("nothing delegated to %s\n", *to)
+		} else {
+			fmt.Println("nothing delegated")
+		}
+		return nil
+	}
+	printWaiting(os.Stdout, tasks, groups, time.Now())
+	return nil
+}
+
+func printWaiting(w io.Writer, tasks []model.Task, groups []engine.Group, now time.Time) {
+	for i, g := range groups {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "Waiting on %s (%d):\n", g.Name, len(g.Indices))
+		for _, idx := range g.Indices {
+			t := tasks[idx]
+			var notes []string
+			if t.DueAt != nil {
+				notes = append(notes, "due "+t.DueAt.Format("Mon Jan 2"))
+			}
+			switch {
+			case engine.FollowUpOverdue(t, now):
+				notes = append(notes, "follow-up overdue since "+t.FollowUpAt.Format("Mon Jan 2"))
+			case t.FollowUpAt != nil:
+				notes = append(notes, "follow up "+t.FollowUpAt.Format("Mon Jan 2"))
+			}
+			line := "- " + t.Title
+			if len(notes) > 0 {
+				line += " (" + strings.Join(notes, "; ") + ")"
+			}
+			fmt.Fprintln(w, line)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/mrbooshehri/actNow/internal/store"
+	"github.com/mrbooshehri/actNow/internal/taskdoc"
+)
+
+func runEdit(st *store.Store, args []string) error {
+	fs := flag.NewFlagSet("edit", flag.ContinueOnError)
+	useEditor := fs.Bool("editor", false, "open the task in $EDITOR")
+	id, rest := splitID(args)
+	if err := fs.Parse(rest); err != nil {
+		return err
+	}
+	if id == "" && fs.NArg() > 0 {
+		id = fs.Arg(0)
+	}
+	if !*useEditor {
+		return fmt.Errorf("edit: nothing to do; pass --editor")
+	}
+
+	tasks, err := loadTasksStrict(st)
+	if err != nil {
+		return err
+	}
+	idx, err := findTask(tasks, id)
+	if err != nil {
+		return err
+	}
+
+	path, err := taskdoc.WriteTemp(tasks[idx])
+	if err != nil {
+		return err
+	}
+	defer os.Remove(path)
+
+	cmd := taskdoc.Command(path)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("editor failed: %w", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	edited, err := taskdoc.Apply(tasks[idx], data)
+	if err != nil {
+		return fmt.Errorf("edit discarded: %w", err)
+	}
+	edited.RecordChanges(tasks[idx], time.Now())
+	tasks[idx] = edited
+	if err := saveTasks(st, tasks); err != nil {
+		return err
+	}
+	fmt.Printf("updated %s %s\n", edited.ID, edited.Title)
+	return nil
+}
+
+// splitID takes a leading ID off args so flags may follow it.
+func splitID(args []string) (string, []string) {
+	if len(args) > 0 && len(args[0]) > 0 && args[0][0] != '-' {
+		return args[0], args[1:]
+	}
+	return "", args
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/mrbooshehri/actNow/internal/config"
+	"github.com/mrbooshehri/actNow/internal/engine"
+	"github.com/mrbooshehri/actNow/internal/format"
+	"github.com/mrbooshehri/actNow/internal/model"
+	"github.com/mrbooshehri/actNow/internal/store"
+)
+
+// autoEliminate returns the remaining tasks and how many were trashed.
+func autoEliminate(st *store.Store, cfg *config.Config, tasks []model.Task) ([]model.Task, int, error) {
+	now := time.Now()
+	stale := engine.StaleEliminations(tasks, cfg.AutoEliminateDays, now)
+	if len(stale) == 0 {
+		return tasks, 0, nil
+	}
+	drop := map[int]bool{}
+	entries := make([]model.TrashEntry, 0, len(stale))
+	for _, idx := range stale {
+		drop[idx] = true
+		reason := tasks[idx].DeleteReason
+		if reason == "" {
+			reason = fmt.Sprintf("untouched for %d days", cfg.AutoEliminateDays)
+		}
+		entries = append(entries, model.TrashEntry{Task: tasks[idx], DeletedAt: now, Reason: reason, Eliminated: true})
+	}
+	if err := appendTrash(st, entries); err != nil {
+		return tasks, 0, err
+	}
+	kept := make([]model.Task, 0, len(tasks)-len(drop))
+	for i, t := range tasks {
+		if !drop[i] {
+			kept = append(kept, t)
+		}
+	}
+	if err := saveTasks(st, kept); err != nil {
+		return tasks, 0, err
+	}
+	return kept, len(entries), nil
+}
+
+func runEliminated(st *store.Store, args []string) error {
+	fs := flag.NewFlagSet("eliminated", flag.ContinueOnError)
+	since := fs.String("since", "", "only show tasks eliminated within this duration, e.g. 30d")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	cutoff := time.Time{}
+	if *since != "" {
+		d, err := engine.ParseDuration(*since)
+		if err != nil {
+			return fmt.Errorf("--since: %w", err)
+		}
+		cutoff = time.Now().Add(-d)
+	}
+
+	data, err := st.LoadTrash()
+	if err != nil {
+		return fmt.Errorf("failed to load trash: %w", err)
+	}
+	var trash []model.TrashEntry
+	if err := store.DecodeTasks(data, &trash); err != nil {
+		return fmt.Errorf("trash file %s: %w", st.TrashPath(), err)
+	}
+	var entries []model.TrashEntry
+	for _, e := range trash {
+		if e.Eliminated && !e.DeletedAt.Before(cutoff) {
+			entries = append(entries, e)
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].DeletedAt.After(entries[j].DeletedAt) })
+	printEliminated(os.Stdout, entries)
+	return nil
+}
+
+func printEliminated(w io.Writer, entries []model.TrashEntry) {
+	if len(entries) == 0 {
+		fmt.Fprintln(w, "nothing eliminated")
+		return
+	}
+	reasons := map[string]int{}
+	for _, e := range entries {
+		reason := e.Reason
+		if reason == "" {
+			reason = "(no reason)"
+		}
+		reasons[reason]++
+		fmt.Fprintf(w, "%s  %s\n    reason: %s\n", e.DeletedAt.Format("2006-01-02"), e.Task.Title, reason)
+	}
+	fmt.Fprintf(w, "\n%s eliminated", format.Plural(len(entries), "task", "tasks"))
+	if len(reasons) > 1 {
+		fmt.Fprintf(w, " for %d different reasons", len(reasons))
+	}
+	fmt.Fprintln(w)
+}
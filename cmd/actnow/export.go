@@ -0,0 +1,70 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mrbooshehri/actNow/internal/engine"
+	"github.com/mrbooshehri/actNow/internal/format"
+	"github.com/mrbooshehri/actNow/internal/model"
+	"github.com/mrbooshehri/actNow/internal/query"
+	"github.com/mrbooshehri/actNow/internal/store"
+	"github.com/mrbooshehri/actNow/internal/taskmd"
+)
+
+func runExport(st *store.Store, args []string) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	outFormat := fs.String("format", "md", "output format (md)")
+	filter := fs.String("filter", "", "only export tasks matching this filter expression")
+	withDone := fs.Bool("done", false, "include done tasks")
+	output := fs.String("o", "", "write to this file instead of standard output")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *outFormat != "md" {
+		return fmt.Errorf("export: unknown format %q (want md)", *outFormat)
+	}
+	expr := *filter
+	if fs.NArg() > 0 {
+		expr = strings.TrimSpace(expr + " " + strings.Join(fs.Args(), " "))
+	}
+	q, err := query.Parse(expr)
+	if err != nil {
+		return fmt.Errorf("invalid filter: %w", err)
+	}
+	tasks, _, err := loadTasks(st)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	cfg := loadConfig(st)
+	var out []model.Task
+	for quadrant := range taskmd.Quadrants {
+		var indices []int
+		for i, t := range tasks {
+			if engine.QuadrantIndex(t) != quadrant || (t.IsDone() && !*withDone) || !q.Match(t, now) {
+				continue
+			}
+			indices = append(indices, i)
+		}
+		engine.SortIndices(tasks, indices, cfg.SortFor(quadrant), cfg.Points())
+		for _, i := range indices {
+			out = append(out, tasks[i])
+		}
+	}
+
+	data := taskmd.Marshal(out, now)
+	if *output == "" {
+		_, err := os.Stdout.Write(data)
+		return err
+	}
+	if err := os.WriteFile(*output, data, 0o644); err != nil {
+		return fmt.Errorf("export: %w", err)
+	}
+	fmt.Printf("exported %s to %s\n", format.Plural(len(out), "task", "tasks"), *output)
+	return nil
+}
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mrbooshehri/actNow/internal/engine"
+	"github.com/mrbooshehri/actNow/internal/format"
+	"github.com/mrbooshehri/actNow/internal/model"
+	"github.com/mrbooshehri/actNow/internal/query"
+	"github.com/mrbooshehri/actNow/internal/store"
+	"github.com/mrbooshehri/actNow/internal/taskmd"
+)
+
+// importChange is one task an import adds (index -1) or updates.
+type importChange struct {
+	index   int
+	task    model.Task
+	changes []string
+}
+
+func runImport(st *store.Store, args []string) error {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	inFormat := fs.String("format", "md", "input format (md)")
+	quadrant := fs.String("quadrant", "", "quadrant for new tasks outside the quadrant sections (iim, inim, niim, nini or 1-4)")
+	dryRun := fs.Bool("dry-run", false, "only show what would change")
+	yes := fs.Bool("yes", false, "apply without asking")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *inFormat != "md" {
+		return fmt.Errorf("import: unknown format %q (want md)", *inFormat)
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("import: pass one file, or - for standard input")
+	}
+	path := fs.Arg(0)
+	q := -1
+	if *quadrant != "" {
+		var ok bool
+		if q, ok = query.QuadrantAliases[strings.ToLower(*quadrant)]; !ok {
+			return fmt.Errorf("import: unknown quadrant %q (want iim, inim, niim, nini or 1-4)", *quadrant)
+		}
+	}
+
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return fmt.Errorf("import: %w", err)
+	}
+	items, err := taskmd.Parse(data)
+	if err != nil {
+		return fmt.Errorf("import: %s: %w", path, err)
+	}
+	tasks, err := loadTasksStrict(st)
+	if err != nil {
+		return err
+	}
+	plan, unchanged, err := planImport(tasks, items, q, loadConfig(st).Points(), time.Now())
+	if err != nil {
+		return fmt.Errorf("import: %s: %w", path, err)
+	}
+
+	printImportPlan(os.Stdout, plan, unchanged)
+	if len(plan) == 0 || *dryRun {
+		return nil
+	}
+	if !*yes {
+		if path == "-" {
+			return fmt.Errorf("import: pass --yes to apply changes read from standard input")
+		}
+		fmt.Printf("Apply %s? [y/N] ", format.Plural(len(plan), "change", "changes"))
+		answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		if a := strings.ToLower(strings.TrimSpace(answer)); a != "y" && a != "yes" {
+			fmt.Println("nothing imported")
+			return nil
+		}
+	}
+
+	now := time.Now()
+	added := 0
+	for _, c := range plan {
+		if c.index < 0 {
+			tasks = append(tasks, c.task)
+			added++
+			continue
+		}
+		c.task.RecordChanges(tasks[c.index], now)
+		tasks[c.index] = c.task
+	}
+	if err := saveTasks(st, tasks); err != nil {
+		return err
+	}
+	fmt.Printf("imported: %d added, %d updated\n", added, len(plan)-added)
+	return nil
+}
+
+// planImport matches items by embedded ID only; the rest are added.
+func planImport(tasks []model.Task, items []taskmd.Item, q int, points map[string]string, now time.Time) ([]importChange, int, error) {
+	var plan []importChange
+	unchanged := 0
+	seen := map[int]int{}
+	newIDs := map[string]int{}
+	order := engine.NextOrder(tasks)
+	for _, it := range items {
+		if effort, ok := it.Fields["Effort"]; ok && effort != "" {
+			if _, err := engine.ParseEffort(effort, points); err != nil {
+				return nil, 0, &taskmd.Error{Line: it.Line, Msg: "Effort: " + err.Error()}
+			}
+		}
+		idx := matchTask(tasks, it)
+		if idx >= 0 {
+			if line, dup := seen[idx]; dup {
+				return nil, 0, &taskmd.Error{Line: it.Line, Msg: fmt.Sprintf("same task as line %d", line)}
+			}
+			seen[idx] = it.Line
+			updated, err := taskmd.Apply(tasks[idx], it, now)
+			if err != nil {
+				return nil, 0, err
+			}
+			changes := model.Changes(tasks[idx], updated)
+			if len(changes) == 0 {
+				unchanged++
+				continue
+			}
+			plan = append(plan, importChange{index: idx, task: updated, changes: changes})
+			continue
+		}
+
+		task := model.NewTask(it.Title, "", false, false, nil)
+		if it.ID != "" {
+			if line, dup := newIDs[it.ID]; dup {
+				return nil, 0, &taskmd.Error{Line: it.Line, Msg: fmt.Sprintf("same task as line %d", line)}
+			}
+			newIDs[it.ID] = it.Line
+			task.ID = it.ID
+		}
+		if it.Quadrant < 0 {
+			if q < 0 {
+				return nil, 0, &taskmd.Error{Line: it.Line, Msg: fmt.Sprintf("%q is outside the quadrant sections; pass --quadrant", it.Title)}
+			}
+			task = engine.SetQuadrant(task, q)
+		}
+		task, err := taskmd.Apply(task, it, now)
+		if err != nil {
+			return nil, 0, err
+		}
+		task = engine.ApplyUrgency(task, now)
+		task.Order = order
+		order++
+		plan = append(plan, importChange{index: -1, task: task})
+	}
+	return plan, unchanged, nil
+}
+
+func matchTask(tasks []model.Task, it taskmd.Item) int {
+	if it.ID == "" {
+		return -1
+	}
+	for i, t := range tasks {
+		if t.ID == it.ID {
+			return i
+		}
+	}
+	return -1
+}
+
+func printImportPlan(w io.Writer, plan []importChange, unchanged int) {
+	for _, c := range plan {
+		if c.index < 0 {
+			fmt.Fprintf(w, "+ %s  [%s]\n", taskLine(c.task), engine.Quadrant(c.task))
+			continue
+		}
+		fmt.Fprintf(w, "~ %s\n", taskLine(c.task))
+		for _, change := range c.changes {
+			fmt.Fprintln(w, "    "+change)
+		}
+	}
+	if unchanged > 0 {
+		fmt.Fprintf(w, "= %d unchanged\n", unchanged)
+	}
+	if len(plan) == 0 {
+		fmt.Fprintln(w, "nothing to import")
+	}
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mrbooshehri/actNow/internal/config"
+	"github.com/mrbooshehri/actNow/internal/engine"
+	"github.com/mrbooshehri/actNow/internal/model"
+	"github.com/mrbooshehri/actNow/internal/query"
+	"github.com/mrbooshehri/actNow/internal/store"
+)
+
+func runList(st *store.Store, args []string) error {
+	fs := flag.NewFlagSet("list", flag.ContinueOnError)
+	filter := fs.String("filter", "", "filter expression, e.g. 'quadrant:iim due<48h'")
+	viewName := fs.String("view", "", "saved view to list through")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	expr := *filter
+	if fs.NArg() > 0 {
+		expr = strings.TrimSpace(expr + " " + strings.Join(fs.Args(), " "))
+	}
+
+	cfg := loadConfig(st)
+	view := config.View{Group: engine.GroupQuadrant}
+	if *viewName != "" {
+		v, ok := cfg.View(*viewName)
+		if !ok {
+			return fmt.Errorf("no view named %q (see actnow views)", *viewName)
+		}
+		view = v
+	}
+
+	viewQuery, err := query.Parse(view.Filter)
+	if err != nil {
+		return fmt.Errorf("view %q: %w", view.Name, err)
+	}
+	q, err := query.Parse(expr)
+	if err != nil {
+		return fmt.Errorf("invalid filter: %w", err)
+	}
+	tasks, _, err := loadTasks(st)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	var indices []int
+	for i, t := range tasks {
+		if viewQuery.Match(t, now) && q.Match(t, now) {
+			indices = append(indices, i)
+		}
+	}
+	engine.SortIndices(tasks, indices, view.Sort, cfg.Points())
+	printGroups(os.Stdout, tasks, engine.GroupTasks(tasks, indices, view.Group))
+	return nil
+}
+
+func runViews(st *store.Store, args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("views takes no arguments")
+	}
+	cfg := loadConfig(st)
+	if len(cfg.Views) == 0 {
+		fmt.Printf("no saved views; add them to %s\n", cfg.Path())
+		return nil
+	}
+	for _, v := range cfg.Views {
+		group := v.Group
+		if group == "" {
+			group = engine.GroupQuadrant
+		}
+		sortBy := v.Sort
+		if sortBy == "" {
+			sortBy = engine.SortManual
+		}
+		fmt.Printf("%-20s %s  (by %s, sort %s)\n", v.Name, v.Filter, group, sortBy)
+	}
+	return nil
+}
+
+func loadConfig(st *store.Store) *config.Config {
+	cfg, err := config.Load(st.Dir())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "config: %v\n", err)
+	}
+	return cfg
+}
+
+func printGroups(w io.Writer, tasks []model.Task, groups []engine.Group) {
+	first := true
+	for _, g := range groups {
+		if len(g.Indices) == 0 {
+			continue
+		}
+		if !first {
+			fmt.Fprintln(w)
+		}
+		first = false
+		fmt.Fprintf(w, "%s (%d)\n", strings.ToUpper(g.Name), len(g.Indices))
+		for _, idx := range g.Indices {
+			fmt.Fprintln(w, "  "+taskLine(tasks[idx]))
+		}
+	}
+	if first {
+		fmt.Fprintln(w, "no matching tasks")
+	}
+}
+
+func taskLine(t model.Task) string {
+	var b strings.Builder
+	b.WriteString(shortID(t.ID))
+	b.WriteString("  ")
+	b.WriteString(statusMark(t.Status))
+	b.WriteString(" ")
+	b.WriteString(t.Title)
+	if t.DueAt != nil {
+		b.WriteString(" (due " + t.DueAt.Format("2006-01-02 15:04") + ")")
+	}
+	for _, tag := range t.Tags {
+		b.WriteString(" #" + tag)
+	}
+	return b.String()
+}
+
+func shortID(id string) string {
+	if len(id) > 8 {
+		return id[:8]
+	}
+	return id
+}
+
+func statusMark(status string) string {
+	switch status {
+	case model.StatusDone:
+		return "[x]"
+	case model.StatusDeferred:
+		return "[-]"
+	default:
+		return "[ ]"
+	}
+}
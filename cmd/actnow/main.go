@@ -7,7 +7,9 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 
-	"github.com/mrbooshehri/actNow/internal/model"
+	"github.com/mrbooshehri/actNow/internal/config"
+	"github.com/mrbooshehri/actNow/internal/engine"
+	"github.com/mrbooshehri/actNow/internal/format"
 	"github.com/mrbooshehri/actNow/internal/store"
 	"github.com/mrbooshehri/actNow/internal/ui"
 )
@@ -19,39 +21,46 @@ func main() {
 		os.Exit(1)
 	}
 
-	data, err := st.Load()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "failed to load tasks: %v\n", err)
-		os.Exit(1)
-	}
-
-	var (
-		tasks        []model.Task
-		corruptFound bool
-	)
-	if err := store.DecodeTasks(data, &tasks); err != nil {
-		if err == store.ErrCorruptData {
-			fmt.Fprintf(os.Stderr, "task file appears corrupted; starting with empty list\n")
-			tasks = []model.Task{}
-			corruptFound = true
-		} else {
-			fmt.Fprintf(os.Stderr, "failed to decode tasks: %v\n", err)
+	if len(os.Args) > 1 {
+		if err := runCommand(st, os.Args[1], os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "actnow: %v\n", err)
 			os.Exit(1)
 		}
+		return
 	}
 
-	for i := range tasks {
-		if tasks[i].Status == "" {
-			tasks[i].Status = model.StatusPending
-		}
-		if tasks[i].CreatedAt.IsZero() {
-			tasks[i].CreatedAt = time.Now()
-		}
+	tasks, corruptFound, err := loadTasks(st)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
 	}
 
-	m := ui.New(st, tasks)
-	if corruptFound {
+	cfg, cfgErr := config.Load(st.Dir())
+	eliminated := 0
+	var elimErr error
+	if !corruptFound {
+		tasks, eliminated, elimErr = autoEliminate(st, cfg, tasks)
+	}
+	m := ui.New(st, cfg, tasks)
+	switch {
+	case corruptFound:
 		m.SetStatus("Corrupt data detected; started empty", true)
+	case cfgErr != nil:
+		m.SetStatus("Config: "+cfgErr.Error(), true)
+	case elimErr != nil:
+		m.SetStatus("Auto-eliminate failed: "+elimErr.Error(), true)
+	case eliminated > 0:
+		m.SetStatus(fmt.Sprintf("Auto-eliminated %s untouched for %d days (see actnow eliminated)",
+			format.Plural(eliminated, "NI+NI task", "NI+NI tasks"), cfg.AutoEliminateDays), false)
+	default:
+		now := time.Now()
+		if n := len(engine.OverdueFollowUps(tasks, now)); n > 0 {
+			m.SetStatus(fmt.Sprintf("%s past follow-up; press w to chase", format.Plural(n, "delegated task", "delegated tasks")), true)
+		} else if reviews, err := loadReviews(st); err == nil && !engine.DailyReviewDone(reviews, now) {
+			if n := len(engine.ReviewQueue(tasks, now)); n > 0 {
+				m.SetStatus(fmt.Sprintf("Today's review is not done yet (%s); press r to start", format.Plural(n, "task", "tasks")), false)
+			}
+		}
 	}
 
 	p := tea.NewProgram(m)
@@ -60,3 +69,94 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+func runCommand(st *store.Store, name string, args []string) error {
+	switch name {
+	case "add":
+		return runAdd(st, args)
+	case "edit":
+		return runEdit(st, args)
+	case "list", "ls":
+		return runList(st, args)
+	case "views":
+		return runViews(st, args)
+	case "eliminated":
+		return runEliminated(st, args)
+	case "delegated", "waiting":
+		return runDelegated(st, args)
+	case "reviews":
+		return runReviews(st, args)
+	case "agenda":
+		return runAgenda(st, args)
+	case "time":
+		return runTime(st, args)
+	case "stats":
+		return runStats(st, args)
+	case "export":
+		return runExport(st, args)
+	case "import":
+		return runImport(st, args)
+	case "done", "defer", "delete", "rm", "tag", "move", "delegate":
+		if name == "rm" {
+			name = "delete"
+		}
+		return runBulk(st, name, args)
+	case "help", "-h", "--help":
+		printUsage()
+		return nil
+	default:
+		printUsage()
+		return fmt.Errorf("unknown command %q", name)
+	}
+}
+
+func printUsage() {
+	fmt.Fprint(os.Stderr, `usage: actnow [command]
+
+With no command, actnow starts the terminal UI.
+
+commands:
+  add <title> [--important] [--urgent] [--due when] [--planned when]
+      [--tags a,b] [--delegate name] [--effort 2h] [--project name]
+                        add a task; dates take tomorrow 9:00, fri, +3d,
+                        in 2h, next monday, eod, eow or YYYY-MM-DD
+  list [--filter expr] [--view name]
+                        list tasks by quadrant, optionally filtered or
+                        through a saved view
+  agenda [--days 7]     list open tasks by due and planned date: overdue,
+                        today, tomorrow, this week and later
+  views                 list saved views
+  export [--format md] [--done] [--filter expr] [-o file]
+                        write open tasks as a Markdown checklist, one
+                        section per quadrant; --done includes done tasks
+  import [--format md] [--quadrant q] [--dry-run] [--yes] <file|->
+                        read a Markdown checklist back, updating tasks by
+                        ID (or title) and adding the rest; shows the
+                        changes and asks before saving
+  edit <id> --editor    open a task in $EDITOR as a front-matter document
+  done [--filter expr] [id...]
+                        mark tasks done
+  defer [--filter expr] [id...]
+                        defer tasks
+  delete [--reason text] [--filter expr] [id...]
+                        move tasks to the trash (~/.actnow/trash.json)
+  tag <+add -remove | tags> [--filter expr] [id...]
+                        add or remove tags, or replace them
+  move <quadrant> [--filter expr] [id...]
+                        reclassify tasks (iim, inim, niim, nini or 1-4)
+  delegate <name> [--filter expr] [id...]
+                        set who tasks are delegated to
+  delegated [--to name]
+                        list what you are waiting on, by person, ready to
+                        paste into a chat message
+  eliminated [--since 30d]
+                        report NI+NI tasks eliminated, with their reasons
+  reviews [--kind daily|weekly]
+                        show the reviews log, newest first, with notes
+  stats [--days 30]     report tasks per quadrant, completion rates, lead
+                        time, escalations, overdue counts and completions
+  time [--since 7d]     report time tracked per task against its effort
+                        estimate (--since 0 for all time)
+  help                  show this message
+`)
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/mrbooshehri/actNow/internal/format"
+	"github.com/mrbooshehri/actNow/internal/model"
+	"github.com/mrbooshehri/actNow/internal/store"
+)
+
+func runReviews(st *store.Store, args []string) error {
+	fs := flag.NewFlagSet("reviews", flag.ContinueOnError)
+	kind := fs.String("kind", "", "only list reviews of this kind (daily or weekly)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *kind != "" && *kind != model.ReviewDaily && *kind != model.ReviewWeekly {
+		return fmt.Errorf("reviews: unknown kind %q (want daily or weekly)", *kind)
+	}
+	reviews, err := loadReviews(st)
+	if err != nil {
+		return err
+	}
+	var out []model.Review
+	for _, r := range reviews {
+		if *kind == "" || r.Kind == *kind {
+			out = append(out, r)
+		}
+	}
+	if len(out) == 0 {
+		fmt.Println("no reviews yet")
+		return nil
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].At.After(out[j].At) })
+	printReviews(os.Stdout, out)
+	return nil
+}
+
+func printReviews(w io.Writer, reviews []model.Review) {
+	for _, r := range reviews {
+		keys := make([]string, 0, len(r.Outcomes))
+		for k := range r.Outcomes {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		outcomes := make([]string, len(keys))
+		for i, k := range keys {
+			outcomes[i] = fmt.Sprintf("%s %d", k, r.Outcomes[k])
+		}
+		line := fmt.Sprintf("%s  %-6s  %s", r.At.Local().Format("2006-01-02 15:04"), r.Kind, format.Plural(r.Tasks, "task", "tasks"))
+		if len(outcomes) > 0 {
+			line += "  (" + strings.Join(outcomes, ", ") + ")"
+		}
+		fmt.Fprintln(w, line)
+		if r.Note != "" {
+			fmt.Fprintln(w, "    "+r.Note)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/mrbooshehri/actNow/internal/engine"
+	"github.com/mrbooshehri/actNow/internal/format"
+	"github.com/mrbooshehri/actNow/internal/store"
+)
+
+func runStats(st *store.Store, args []string) error {
+	fs := flag.NewFlagSet("stats", flag.ContinueOnError)
+	days := fs.Int("days", 30, "how many days back to report on")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *days <= 0 {
+		return fmt.Errorf("stats: --days must be positive")
+	}
+	tasks, _, err := loadTasks(st)
+	if err != nil {
+		return err
+	}
+	for _, line := range format.StatsLines(engine.ComputeStats(tasks, time.Now(), *days)) {
+		fmt.Println(line)
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mrbooshehri/actNow/internal/engine"
+	"github.com/mrbooshehri/actNow/internal/model"
+	"github.com/mrbooshehri/actNow/internal/store"
+)
+
+func loadTasks(st *store.Store) ([]model.Task, bool, error) {
+	data, err := st.Load()
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to load tasks: %w", err)
+	}
+
+	var (
+		tasks        []model.Task
+		corruptFound bool
+	)
+	if err := store.DecodeTasks(data, &tasks); err != nil {
+		if err != store.ErrCorruptData {
+			return nil, false, fmt.Errorf("failed to decode tasks: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "task file appears corrupted; starting with empty list\n")
+		tasks = []model.Task{}
+		corruptFound = true
+	}
+
+	now := time.Now()
+	for i := range tasks {
+		tasks[i] = engine.ApplyUrgency(tasks[i], now)
+		if tasks[i].Status == "" {
+			tasks[i].Status = model.StatusPending
+		}
+		if tasks[i].CreatedAt.IsZero() {
+			tasks[i].CreatedAt = now
+		}
+	}
+	engine.NormalizeOrder(tasks)
+	return tasks, corruptFound, nil
+}
+
+// loadTasksStrict refuses corrupt data instead of overwriting it.
+func loadTasksStrict(st *store.Store) ([]model.Task, error) {
+	tasks, corrupt, err := loadTasks(st)
+	if err != nil {
+		return nil, err
+	}
+	if corrupt {
+		return nil, store.ErrCorruptData
+	}
+	return tasks, nil
+}
+
+func saveTasks(st *store.Store, tasks []model.Task) error {
+	engine.Escalate(tasks, time.Now())
+	data, err := store.EncodeTasks(tasks)
+	if err != nil {
+		return fmt.Errorf("failed to encode tasks: %w", err)
+	}
+	if err := st.Save(data); err != nil {
+		return fmt.Errorf("failed to save tasks: %w", err)
+	}
+	return nil
+}
+
+// appendTrash adds entries to the trash file.
+func appendTrash(st *store.Store, entries []model.TrashEntry) error {
+	data, err := st.LoadTrash()
+	if err != nil {
+		return fmt.Errorf("failed to load trash: %w", err)
+	}
+	var trash []model.TrashEntry
+	if err := store.DecodeTasks(data, &trash); err != nil {
+		return fmt.Errorf("trash file %s: %w", st.TrashPath(), err)
+	}
+	data, err = store.EncodeTasks(append(trash, entries...))
+	if err != nil {
+		return fmt.Errorf("failed to encode trash: %w", err)
+	}
+	if err := st.SaveTrash(data); err != nil {
+		return fmt.Errorf("failed to save trash: %w", err)
+	}
+	return nil
+}
+
+// loadReviews reads the log of completed reviews.
+func loadReviews(st *store.Store) ([]model.Review, error) {
+	data, err := st.LoadReviews()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load reviews: %w", err)
+	}
+	var reviews []model.Review
+	if err := store.DecodeTasks(data, &reviews); err != nil {
+		return nil, fmt.Errorf("reviews file %s: %w", st.ReviewsPath(), err)
+	}
+	return reviews, nil
+}
+
+// findTask resolves a full task ID or a unique, case-insensitive prefix.
+func findTask(tasks []model.Task, id string) (int, error) {
+	id = strings.ToUpper(strings.TrimSpace(id))
+	if id == "" {
+		return 0, fmt.Errorf("task id is required")
+	}
+	match := -1
+	for i, t := range tasks {
+		if t.ID == id {
+			return i, nil
+		}
+		if strings.HasPrefix(t.ID, id) {
+			if match >= 0 {
+				return 0, fmt.Errorf("task id %q is ambiguous", id)
+			}
+			match = i
+		}
+	}
+	if match < 0 {
+		return 0, fmt.Errorf("no task with id %q", id)
+	}
+	return match, nil
+}
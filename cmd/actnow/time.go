@@ -0,0 +1,67 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/mrbooshehri/actNow/internal/engine"
+	"github.com/mrbooshehri/actNow/internal/format"
+	"github.com/mrbooshehri/actNow/internal/model"
+	"github.com/mrbooshehri/actNow/internal/store"
+)
+
+func runTime(st *store.Store, args []string) error {
+	fs := flag.NewFlagSet("time", flag.ContinueOnError)
+	since := fs.String("since", "7d", "only count time tracked within this duration (0 for all)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	now := time.Now()
+	from := time.Time{}
+	if *since != "0" && *since != "" {
+		d, err := engine.ParseDuration(*since)
+		if err != nil {
+			return fmt.Errorf("--since: %w", err)
+		}
+		from = now.Add(-d)
+	}
+	tasks, _, err := loadTasks(st)
+	if err != nil {
+		return err
+	}
+	rows := engine.TimeReport(tasks, from, now, loadConfig(st).Points())
+	if len(rows) == 0 {
+		fmt.Println("no time tracked")
+		return nil
+	}
+	printTimeReport(os.Stdout, tasks, rows)
+	return nil
+}
+
+func printTimeReport(w io.Writer, tasks []model.Task, rows []engine.TimeRow) {
+	var total time.Duration
+	over := 0
+	for _, r := range rows {
+		t := tasks[r.Index]
+		total += r.Spent
+		estimate, ratio, note := "", "", ""
+		if r.Estimate > 0 {
+			estimate = "of " + format.Hours(r.Estimate)
+			ratio = fmt.Sprintf("%d%%", int(r.Spent*100/r.Estimate))
+		}
+		switch {
+		case r.Running:
+			note = "running"
+		case r.Over():
+			note = "over"
+		}
+		if r.Over() {
+			over++
+		}
+		fmt.Fprintf(w, "%8s  %9s  %4s  %-7s  %s  [%s]\n", format.Hours(r.Spent), estimate, ratio, note, t.Title, t.ID)
+	}
+	fmt.Fprintf(w, "total %s on %s, %d over estimate\n", format.Hours(total), format.Plural(len(rows), "task", "tasks"), over)
+}
@@ -0,0 +1,293 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/mrbooshehri/actNow/internal/engine"
+	"github.com/mrbooshehri/actNow/internal/query"
+)
+
+const fileName = "config.json"
+
+var ErrCorruptConfig = errors.New("config file is corrupted")
+
+// View is a named saved filter with its own grouping and sort order.
+type View struct {
+	Name   string `json:"name"`
+	Filter string `json:"filter"`
+	Group  string `json:"group,omitempty"`
+	Sort   string `json:"sort,omitempty"`
+}
+
+type Config struct {
+	path string
+	// loadErr is what Load reported; such a config is not saved over the
+	// file, which still holds what Load dropped.
+	loadErr error
+	Views   []View `json:"views"`
+	// QuadrantSort maps engine.QuadrantKeys to the sort order used inside
+	// that quadrant of the main grid.
+	QuadrantSort map[string]string `json:"quadrant_sort,omitempty"`
+	// SkipConfirm turns off confirmation dialogs, except for purging the
+	// trash.
+	SkipConfirm bool `json:"skip_confirm,omitempty"`
+	// AutoEliminateDays moves open NI+NI tasks untouched for that many
+	// days to the trash on launch. Zero turns it off.
+	AutoEliminateDays int `json:"auto_eliminate_days,omitempty"`
+	// DailyCapacity is how much planned effort fits in a day, e.g. "6h".
+	DailyCapacity string `json:"daily_capacity,omitempty"`
+	// StoryPoints maps story points to effort, e.g. {"3": "4h"}.
+	StoryPoints map[string]string `json:"story_points,omitempty"`
+	// PomodoroWork and PomodoroBreak are the pomodoro phase lengths, e.g.
+	// "25m" and "5m".
+	PomodoroWork  string `json:"pomodoro_work,omitempty"`
+	PomodoroBreak string `json:"pomodoro_break,omitempty"`
+	// PomodoroSilent turns off the bell at the end of a pomodoro phase.
+	PomodoroSilent bool `json:"pomodoro_silent,omitempty"`
+}
+
+// DefaultCapacity is the daily capacity when none is configured.
+const DefaultCapacity = 6 * time.Hour
+
+// Default pomodoro phase lengths.
+const (
+	DefaultPomodoroWork  = 25 * time.Minute
+	DefaultPomodoroBreak = 5 * time.Minute
+)
+
+func DefaultViews() []View {
+	return []View{
+		{Name: "Ops on-call", Filter: "tag:ops status:open", Group: engine.GroupQuadrant, Sort: engine.SortDue},
+		{Name: "Waiting on others", Filter: "delegate:any status:open", Group: engine.GroupDelegate, Sort: engine.SortDue},
+		{Name: "Due this week", Filter: "due<7d status:open", Group: engine.GroupProject, Sort: engine.SortDue},
+	}
+}
+
+func Default(dir string) *Config {
+	return &Config{path: filepath.Join(dir, fileName), Views: DefaultViews()}
+}
+
+// Load reads the config from dir. A missing file yields the defaults.
+// The returned config is usable even when an error is reported.
+func Load(dir string) (*Config, error) {
+	cfg := Default(dir)
+	b, err := os.ReadFile(cfg.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cfg, nil
+		}
+		return cfg, err
+	}
+	// Views keeps the defaults unless the file has a "views" key.
+	if err := json.Unmarshal(b, cfg); err != nil {
+		cfg = Default(dir)
+		cfg.loadErr = ErrCorruptConfig
+		return cfg, ErrCorruptConfig
+	}
+	// Invalid views are dropped so the rest of the config stays usable;
+	// the first problem is reported.
+	var firstErr error
+	valid := cfg.Views[:0]
+	for _, v := range cfg.Views {
+		if err := v.Validate(); err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		valid = append(valid, v)
+	}
+	cfg.Views = valid
+	for key, by := range cfg.QuadrantSort {
+		if !contains(engine.QuadrantKeys, key) || !contains(engine.SortOrders, by) {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("quadrant_sort: invalid entry %q: %q", key, by)
+			}
+			delete(cfg.QuadrantSort, key)
+		}
+	}
+	if cfg.DailyCapacity != "" {
+		if _, err := engine.ParseEffort(cfg.DailyCapacity, nil); err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("daily_capacity: %w", err)
+			}
+			cfg.DailyCapacity = ""
+		}
+	}
+	for points, effort := range cfg.StoryPoints {
+		if _, err := engine.ParseEffort(effort, nil); err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("story_points: %s: %w", points, err)
+			}
+			delete(cfg.StoryPoints, points)
+		}
+	}
+	for _, f := range []struct {
+		name  string
+		value *string
+	}{{"pomodoro_work", &cfg.PomodoroWork}, {"pomodoro_break", &cfg.PomodoroBreak}} {
+		if *f.value == "" {
+			continue
+		}
+		if d, err := engine.ParseDuration(*f.value); err != nil || d <= 0 {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("%s: invalid length %q", f.name, *f.value)
+			}
+			*f.value = ""
+		}
+	}
+	if cfg.AutoEliminateDays < 0 {
+		if firstErr == nil {
+			firstErr = fmt.Errorf("auto_eliminate_days: must not be negative")
+		}
+		cfg.AutoEliminateDays = 0
+	}
+	cfg.loadErr = firstErr
+	return cfg, firstErr
+}
+
+func (c *Config) Path() string {
+	return c.path
+}
+
+func (c *Config) Save() error {
+	if c.loadErr != nil {
+		return fmt.Errorf("not overwriting %s: %w", c.path, c.loadErr)
+	}
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	return c.write(data)
+}
+
+// SaveSort writes the sort order of quadrant q into the file on disk,
+// leaving the rest of it as it is.
+func (c *Config) SaveSort(q int) error {
+	raw := map[string]json.RawMessage{}
+	b, err := os.ReadFile(c.path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err == nil {
+		if err := json.Unmarshal(b, &raw); err != nil {
+			return fmt.Errorf("not overwriting %s: %w", c.path, ErrCorruptConfig)
+		}
+	}
+	sorts := map[string]string{}
+	if prev, ok := raw["quadrant_sort"]; ok {
+		if err := json.Unmarshal(prev, &sorts); err != nil {
+			return fmt.Errorf("quadrant_sort: %w", err)
+		}
+	}
+	sorts[engine.QuadrantKeys[q]] = c.SortFor(q)
+	if raw["quadrant_sort"], err = json.Marshal(sorts); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(raw, "", "  ")
+	if err != nil {
+		return err
+	}
+	return c.write(data)
+}
+
+func (c *Config) write(data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(c.path, data, 0o600)
+}
+
+// Capacity returns the daily capacity used for planning.
+func (c *Config) Capacity() time.Duration {
+	if c == nil || c.DailyCapacity == "" {
+		return DefaultCapacity
+	}
+	d, err := engine.ParseEffort(c.DailyCapacity, nil)
+	if err != nil {
+		return DefaultCapacity
+	}
+	return d
+}
+
+// Pomodoro returns the work and break lengths of a pomodoro.
+func (c *Config) Pomodoro() (work, rest time.Duration) {
+	work, rest = DefaultPomodoroWork, DefaultPomodoroBreak
+	if c == nil {
+		return work, rest
+	}
+	if d, err := engine.ParseDuration(c.PomodoroWork); err == nil && d > 0 {
+		work = d
+	}
+	if d, err := engine.ParseDuration(c.PomodoroBreak); err == nil && d > 0 {
+		rest = d
+	}
+	return work, rest
+}
+
+// Points returns the story point mapping, nil meaning the defaults.
+func (c *Config) Points() map[string]string {
+	if c == nil || len(c.StoryPoints) == 0 {
+		return nil
+	}
+	return c.StoryPoints
+}
+
+// SortFor returns the sort order for quadrant index q.
+func (c *Config) SortFor(q int) string {
+	if c == nil || q < 0 || q >= len(engine.QuadrantKeys) {
+		return engine.SortManual
+	}
+	if by := c.QuadrantSort[engine.QuadrantKeys[q]]; by != "" {
+		return by
+	}
+	return engine.SortManual
+}
+
+func (c *Config) SetSort(q int, by string) {
+	if c.QuadrantSort == nil {
+		c.QuadrantSort = map[string]string{}
+	}
+	c.QuadrantSort[engine.QuadrantKeys[q]] = by
+}
+
+// View looks up a saved view by case-insensitive name.
+func (c *Config) View(name string) (View, bool) {
+	for _, v := range c.Views {
+		if strings.EqualFold(v.Name, name) {
+			return v, true
+		}
+	}
+	return View{}, false
+}
+
+func (v View) Validate() error {
+	if strings.TrimSpace(v.Name) == "" {
+		return fmt.Errorf("view without a name")
+	}
+	if _, err := query.Parse(v.Filter); err != nil {
+		return fmt.Errorf("view %q: filter: %w", v.Name, err)
+	}
+	if !contains(engine.Groupings, v.Group) && v.Group != "" {
+		return fmt.Errorf("view %q: unknown group %q (want %s)", v.Name, v.Group, strings.Join(engine.Groupings, ", "))
+	}
+	if !contains(engine.SortOrders, v.Sort) && v.Sort != "" {
+		return fmt.Errorf("view %q: unknown sort %q (want %s)", v.Name, v.Sort, strings.Join(engine.SortOrders, ", "))
+	}
+	return nil
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,175 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoadMissingUsesDefaults(t *testing.T) {
+	cfg, err := Load(t.TempDir())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(cfg.Views) != len(DefaultViews()) {
+		t.Fatalf("expected default views, got %+v", cfg.Views)
+	}
+}
+
+func TestSaveAndLoad(t *testing.T) {
+	dir := t.TempDir()
+	cfg := Default(dir)
+	cfg.Views = []View{{Name: "Mine", Filter: "tag:ops", Group: "project", Sort: "due"}}
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	loaded, err := Load(dir)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if v, ok := loaded.View("mine"); !ok || v.Group != "project" {
+		t.Fatalf("expected saved view, got %+v", loaded.Views)
+	}
+}
+
+func TestQuadrantSort(t *testing.T) {
+	dir := t.TempDir()
+	cfg := Default(dir)
+	if got := cfg.SortFor(0); got != "manual" {
+		t.Fatalf("expected manual by default, got %s", got)
+	}
+	cfg.SetSort(1, "planned")
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	loaded, err := Load(dir)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if got := loaded.SortFor(1); got != "planned" {
+		t.Fatalf("expected planned, got %s", got)
+	}
+}
+
+func TestLoadDropsInvalidViews(t *testing.T) {
+	dir := t.TempDir()
+	data := `{"views":[{"name":"ok","filter":"tag:ops"},{"name":"bad","filter":"colour:red"},{"name":"worse","filter":"","group":"team"}]}`
+	if err := os.WriteFile(filepath.Join(dir, fileName), []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := Load(dir)
+	if err == nil || !strings.Contains(err.Error(), `view "bad"`) {
+		t.Fatalf("expected error for bad view, got %v", err)
+	}
+	if len(cfg.Views) != 1 || cfg.Views[0].Name != "ok" {
+		t.Fatalf("expected only the valid view, got %+v", cfg.Views)
+	}
+}
+
+func TestCapacityAndStoryPoints(t *testing.T) {
+	dir := t.TempDir()
+	data := `{"daily_capacity":"5h","story_points":{"3":"6h","8":"soon"}}`
+	if err := os.WriteFile(filepath.Join(dir, fileName), []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := Load(dir)
+	if err == nil || !strings.Contains(err.Error(), "story_points: 8") {
+		t.Fatalf("expected error for the invalid mapping, got %v", err)
+	}
+	if got := cfg.Capacity(); got != 5*time.Hour {
+		t.Fatalf("expected 5h capacity, got %v", got)
+	}
+	if got := cfg.Points(); len(got) != 1 || got["3"] != "6h" {
+		t.Fatalf("expected only the valid mapping, got %v", got)
+	}
+	if got := Default(dir).Capacity(); got != DefaultCapacity {
+		t.Fatalf("expected default capacity, got %v", got)
+	}
+}
+
+func TestPomodoro(t *testing.T) {
+	dir := t.TempDir()
+	if work, rest := Default(dir).Pomodoro(); work != DefaultPomodoroWork || rest != DefaultPomodoroBreak {
+		t.Fatalf("expected default pomodoro, got %v/%v", work, rest)
+	}
+	data := `{"pomodoro_work":"50m","pomodoro_break":"later"}`
+	if err := os.WriteFile(filepath.Join(dir, fileName), []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := Load(dir)
+	if err == nil || !strings.Contains(err.Error(), "pomodoro_break") {
+		t.Fatalf("expected error for the invalid break, got %v", err)
+	}
+	if work, rest := cfg.Pomodoro(); work != 50*time.Minute || rest != DefaultPomodoroBreak {
+		t.Fatalf("expected 50m/default, got %v/%v", work, rest)
+	}
+}
+
+func TestSaveSortKeepsTheFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, fileName)
+	data := `{"views":[{"name":"bad","filter":"colour:red"}],"quadrant_sort":{"iim":"due"}}`
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := Load(dir)
+	if err == nil {
+		t.Fatal("expected error for bad view")
+	}
+	if err := cfg.Save(); err == nil {
+		t.Fatal("expected Save to refuse a config that failed to load")
+	}
+	cfg.SetSort(1, "planned")
+	if err := cfg.SaveSort(1); err != nil {
+		t.Fatalf("save sort: %v", err)
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`"colour:red"`, `"iim": "due"`, `"inim": "planned"`} {
+		if !strings.Contains(string(b), want) {
+			t.Fatalf("expected %s in saved config, got %s", want, b)
+		}
+	}
+
+	if err := os.WriteFile(path, []byte("{broken"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, _ = Load(dir)
+	cfg.SetSort(0, "due")
+	if err := cfg.SaveSort(0); err == nil {
+		t.Fatal("expected SaveSort to refuse a corrupted file")
+	}
+	if b, _ := os.ReadFile(path); string(b) != "{broken" {
+		t.Fatalf("corrupted file was overwritten: %s", b)
+	}
+}
+
+func TestSaveSortKeepsDefaultViews(t *testing.T) {
+	dir := t.TempDir()
+	cfg, err := Load(dir)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	cfg.SetSort(0, "due")
+	if err := cfg.SaveSort(0); err != nil {
+		t.Fatalf("save sort: %v", err)
+	}
+	loaded, err := Load(dir)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if len(loaded.Views) != len(DefaultViews()) || loaded.SortFor(0) != "due" {
+		t.Fatalf("expected the default views and the saved sort, got %+v %v", loaded.Views, loaded.QuadrantSort)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, fileName), []byte(`{"views":[]}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if loaded, _ := Load(dir); len(loaded.Views) != 0 {
+		t.Fatalf("expected an explicit empty list to remove the views, got %+v", loaded.Views)
+	}
+}
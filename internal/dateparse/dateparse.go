@@ -0,0 +1,242 @@
+// Package dateparse reads the dates people type: "tomorrow 9:00", "fri",
+// "+3d", "in 2h", "next monday", "eod", "eow" and ISO dates.
+package dateparse
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mrbooshehri/actNow/internal/engine"
+)
+
+// DayStart is the time given to dates typed without one; DayEnd is the
+// end of the working day used by eod and eow.
+const (
+	DayStart = 9
+	DayEnd   = 17
+)
+
+var isoLayouts = []string{
+	"2006-01-02T15:04:05Z07:00",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "sunday": time.Sunday,
+	"mon": time.Monday, "monday": time.Monday,
+	"tue": time.Tuesday, "tues": time.Tuesday, "tuesday": time.Tuesday,
+	"wed": time.Wednesday, "wednesday": time.Wednesday,
+	"thu": time.Thursday, "thur": time.Thursday, "thurs": time.Thursday, "thursday": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday,
+}
+
+var durationWords = map[string]string{
+	"minute": "m", "minutes": "m", "min": "m", "mins": "m",
+	"hour": "h", "hours": "h", "hr": "h", "hrs": "h",
+	"day": "d", "days": "d",
+	"week": "w", "weeks": "w",
+}
+
+// Parse reads s relative to now, in now's location:
+//
+//   - ISO dates and times: 2024-03-08, 2024-03-08 14:30, RFC 3339
+//   - today, tomorrow, a weekday (the next one after today) or
+//     next <weekday> (that day in the following week), each optionally
+//     followed by a time: 9, 9:30, 9am, 5pm, 17:00, noon
+//   - a time alone: today at that time, or tomorrow if it has passed
+//   - offsets from now: +3d, 2h30m, in 2h, in 3 days; days and weeks
+//     keep the time of day
+//   - eod (today at DayEnd, or the next working day once that has passed)
+//     and eow (Friday at DayEnd, or the coming Friday once that has passed)
+//
+// Dates without a time get DayStart.
+func Parse(s string, now time.Time) (time.Time, error) {
+	s = strings.Join(strings.Fields(s), " ")
+	if s == "" {
+		return time.Time{}, fmt.Errorf("empty date")
+	}
+	for _, layout := range isoLayouts {
+		if t, err := time.ParseInLocation(layout, s, now.Location()); err == nil {
+			if layout == "2006-01-02" {
+				t = t.Add(DayStart * time.Hour)
+			}
+			return t, nil
+		}
+	}
+	s = strings.ToLower(s)
+	if days, d, ok := parseOffset(s); ok {
+		return now.AddDate(0, 0, days).Add(d).Truncate(time.Minute), nil
+	}
+	switch s {
+	case "eod":
+		t := at(now, DayEnd, 0)
+		if !t.After(now) {
+			t = t.AddDate(0, 0, 1)
+			for t.Weekday() == time.Saturday || t.Weekday() == time.Sunday {
+				t = t.AddDate(0, 0, 1)
+			}
+		}
+		return t, nil
+	case "eow":
+		days := (int(time.Friday) - int(now.Weekday()) + 7) % 7
+		t := at(now.AddDate(0, 0, days), DayEnd, 0)
+		if !t.After(now) {
+			t = t.AddDate(0, 0, 7)
+		}
+		return t, nil
+	}
+	if h, m, ok := ParseClock(s); ok {
+		t := at(now, h, m)
+		if !t.After(now) {
+			t = t.AddDate(0, 0, 1)
+		}
+		return t, nil
+	}
+
+	words := strings.Fields(s)
+	day, n, ok := parseDay(words, now)
+	if !ok {
+		return time.Time{}, fmt.Errorf("unrecognized date %q (try tomorrow 9:00, fri, +3d, in 2h, eod or YYYY-MM-DD)", s)
+	}
+	rest := strings.TrimPrefix(strings.Join(words[n:], " "), "at ")
+	if rest == "" {
+		return at(day, DayStart, 0), nil
+	}
+	h, m, ok := ParseClock(rest)
+	if !ok {
+		return time.Time{}, fmt.Errorf("unrecognized time %q in %q", rest, s)
+	}
+	return at(day, h, m), nil
+}
+
+// parseDay also returns the number of words used.
+func parseDay(words []string, now time.Time) (time.Time, int, bool) {
+	switch words[0] {
+	case "today":
+		return now, 1, true
+	case "tomorrow", "tmr", "tmrw":
+		return now.AddDate(0, 0, 1), 1, true
+	case "next":
+		if len(words) < 2 {
+			return time.Time{}, 0, false
+		}
+		wd, ok := weekdays[words[1]]
+		if !ok {
+			if words[1] == "week" {
+				return nextWeekday(now, time.Monday, true), 2, true
+			}
+			return time.Time{}, 0, false
+		}
+		return nextWeekday(now, wd, true), 2, true
+	}
+	if wd, ok := weekdays[words[0]]; ok {
+		return nextWeekday(now, wd, false), 1, true
+	}
+	return time.Time{}, 0, false
+}
+
+// nextWeekday returns wd after today, or in next week's Monday-Sunday.
+func nextWeekday(now time.Time, wd time.Weekday, nextWeek bool) time.Time {
+	if !nextWeek {
+		days := (int(wd) - int(now.Weekday()) + 7) % 7
+		if days == 0 {
+			days = 7
+		}
+		return now.AddDate(0, 0, days)
+	}
+	monday := now.AddDate(0, 0, -((int(now.Weekday())+6)%7)+7)
+	return monday.AddDate(0, 0, (int(wd)+6)%7)
+}
+
+var offsetPartRe = regexp.MustCompile(`(\d+)([a-z]+)`)
+
+// parseOffset splits off whole days, to be added on the calendar.
+func parseOffset(s string) (int, time.Duration, bool) {
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "in "), "+")
+	words := strings.Fields(s)
+	for i, w := range words {
+		if unit, ok := durationWords[w]; ok && i > 0 {
+			words[i] = unit
+		}
+	}
+	s = strings.Join(words, "")
+	if s == "" || s[0] < '0' || s[0] > '9' {
+		return 0, 0, false
+	}
+	if _, err := engine.ParseDuration(s); err != nil {
+		return 0, 0, false
+	}
+	days := 0
+	var d time.Duration
+	for _, part := range offsetPartRe.FindAllStringSubmatch(s, -1) {
+		n, _ := strconv.Atoi(part[1])
+		switch part[2] {
+		case "d":
+			days += n
+		case "w":
+			days += 7 * n
+		default:
+			rest, _ := engine.ParseDuration(part[0])
+			d += rest
+		}
+	}
+	return days, d, true
+}
+
+// ParseClock reads a time of day: 9, 9:30, 9am, 9:30pm, 17:00 or noon.
+func ParseClock(s string) (int, int, bool) {
+	if s == "noon" {
+		return 12, 0, true
+	}
+	offset := -1
+	switch {
+	case strings.HasSuffix(s, "am"):
+		offset, s = 0, strings.TrimSpace(strings.TrimSuffix(s, "am"))
+	case strings.HasSuffix(s, "pm"):
+		offset, s = 12, strings.TrimSpace(strings.TrimSuffix(s, "pm"))
+	}
+	hs, ms, hasMin := strings.Cut(s, ":")
+	h, err := strconv.Atoi(hs)
+	if err != nil || len(hs) > 2 || !digits(hs) {
+		return 0, 0, false
+	}
+	m := 0
+	if hasMin {
+		if m, err = strconv.Atoi(ms); err != nil || len(ms) != 2 || !digits(ms) || m > 59 {
+			return 0, 0, false
+		}
+	}
+	if offset >= 0 {
+		if h < 1 || h > 12 {
+			return 0, 0, false
+		}
+		h = h%12 + offset
+	} else if !hasMin && len(hs) == 0 {
+		return 0, 0, false
+	}
+	if h > 23 {
+		return 0, 0, false
+	}
+	return h, m, true
+}
+
+// digits reports whether s is all ASCII digits; strconv.Atoi also takes a sign.
+func digits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func at(day time.Time, h, m int) time.Time {
+	y, mo, d := day.Date()
+	return time.Date(y, mo, d, h, m, 0, 0, day.Location())
+}
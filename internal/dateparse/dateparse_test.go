@@ -0,0 +1,128 @@
+package dateparse
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	// Wednesday, 10:30.
+	now := time.Date(2024, 3, 6, 10, 30, 0, 0, time.UTC)
+	date := func(day, h, m int) time.Time { return time.Date(2024, 3, day, h, m, 0, 0, time.UTC) }
+
+	cases := []struct {
+		in   string
+		want time.Time
+	}{
+		{"2024-03-20", date(20, 9, 0)},
+		{"2024-03-20 14:15", date(20, 14, 15)},
+		{"2024-03-20T14:15", date(20, 14, 15)},
+		{"2024-03-20T14:15:00Z", date(20, 14, 15)},
+		{"today", date(6, 9, 0)},
+		{"today 16:00", date(6, 16, 0)},
+		{"tomorrow", date(7, 9, 0)},
+		{"tomorrow 9:00", date(7, 9, 0)},
+		{"Tomorrow at 5pm", date(7, 17, 0)},
+		{"tmrw 12am", date(7, 0, 0)},
+		{"fri", date(8, 9, 0)},
+		{"friday 2:30pm", date(8, 14, 30)},
+		{"wed", date(13, 9, 0)},
+		{"mon", date(11, 9, 0)},
+		{"next monday", date(11, 9, 0)},
+		{"next fri", date(15, 9, 0)},
+		{"next wed 8", date(13, 8, 0)},
+		{"next week", date(11, 9, 0)},
+		{"+3d", date(9, 10, 30)},
+		{"3d", date(9, 10, 30)},
+		{"in 2h", date(6, 12, 30)},
+		{"in 1h30m", date(6, 12, 0)},
+		{"in 3 days", date(9, 10, 30)},
+		{"in 1 week", date(13, 10, 30)},
+		{"eod", date(6, 17, 0)},
+		{"eow", date(8, 17, 0)},
+		{"noon", date(6, 12, 0)},
+		{"11am", date(6, 11, 0)},
+		{"9am", date(7, 9, 0)},
+		{"17:45", date(6, 17, 45)},
+	}
+	for _, c := range cases {
+		got, err := Parse(c.in, now)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", c.in, err)
+			continue
+		}
+		if !got.Equal(c.want) {
+			t.Errorf("Parse(%q) = %s, want %s", c.in, got.Format(time.RFC3339), c.want.Format(time.RFC3339))
+		}
+	}
+
+	for _, in := range []string{"", "someday", "next", "next year", "fri 25:00", "tomorrow 13pm", "in", "+", "2024-02-30", "9:5",
+		"tomorrow +9", "tomorrow -0", "fri -9pm", "fri 9:+5", "+9:00"} {
+		if got, err := Parse(in, now); err == nil {
+			t.Errorf("Parse(%q) = %s, want an error", in, got)
+		}
+	}
+}
+
+func TestParseWeekend(t *testing.T) {
+	// Saturday evening: eod, eow and weekdays roll over to the coming week.
+	now := time.Date(2024, 3, 9, 20, 0, 0, 0, time.UTC)
+	cases := map[string]time.Time{
+		"eow":       time.Date(2024, 3, 15, 17, 0, 0, 0, time.UTC),
+		"sat":       time.Date(2024, 3, 16, 9, 0, 0, 0, time.UTC),
+		"mon":       time.Date(2024, 3, 11, 9, 0, 0, 0, time.UTC),
+		"next mon":  time.Date(2024, 3, 11, 9, 0, 0, 0, time.UTC),
+		"next sun":  time.Date(2024, 3, 17, 9, 0, 0, 0, time.UTC),
+		"eod":       time.Date(2024, 3, 11, 17, 0, 0, 0, time.UTC),
+		"8pm":       time.Date(2024, 3, 10, 20, 0, 0, 0, time.UTC),
+		"today 9pm": time.Date(2024, 3, 9, 21, 0, 0, 0, time.UTC),
+	}
+	for in, want := range cases {
+		got, err := Parse(in, now)
+		if err != nil || !got.Equal(want) {
+			t.Errorf("Parse(%q) = %s, %v; want %s", in, got, err, want)
+		}
+	}
+}
+
+func TestParseAfterDayEnd(t *testing.T) {
+	cases := []struct {
+		now  time.Time
+		in   string
+		want time.Time
+	}{
+		// Wednesday evening.
+		{time.Date(2024, 3, 6, 18, 0, 0, 0, time.UTC), "eod", time.Date(2024, 3, 7, 17, 0, 0, 0, time.UTC)},
+		{time.Date(2024, 3, 6, 18, 0, 0, 0, time.UTC), "eow", time.Date(2024, 3, 8, 17, 0, 0, 0, time.UTC)},
+		// Friday evening.
+		{time.Date(2024, 3, 8, 18, 0, 0, 0, time.UTC), "eod", time.Date(2024, 3, 11, 17, 0, 0, 0, time.UTC)},
+		{time.Date(2024, 3, 8, 18, 0, 0, 0, time.UTC), "eow", time.Date(2024, 3, 15, 17, 0, 0, 0, time.UTC)},
+	}
+	for _, c := range cases {
+		got, err := Parse(c.in, c.now)
+		if err != nil || !got.Equal(c.want) {
+			t.Errorf("Parse(%q) at %s = %s, %v; want %s", c.in, c.now, got, err, c.want)
+		}
+	}
+}
+
+func TestParseOffsetAcrossDST(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("no time zone data:", err)
+	}
+	// Clocks go forward on Sunday 2024-03-10.
+	now := time.Date(2024, 3, 9, 12, 0, 0, 0, loc)
+	cases := map[string]time.Time{
+		"+1d":       time.Date(2024, 3, 10, 12, 0, 0, 0, loc),
+		"in 1 week": time.Date(2024, 3, 16, 12, 0, 0, 0, loc),
+		"1d2h":      time.Date(2024, 3, 10, 14, 0, 0, 0, loc),
+		"in 24h":    time.Date(2024, 3, 10, 13, 0, 0, 0, loc),
+	}
+	for in, want := range cases {
+		got, err := Parse(in, now)
+		if err != nil || !got.Equal(want) {
+			t.Errorf("Parse(%q) = %s, %v; want %s", in, got, err, want)
+		}
+	}
+}
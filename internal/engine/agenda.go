@@ -0,0 +1,84 @@
+package engine
+
+import (
+	"sort"
+	"time"
+
+	"github.com/mrbooshehri/actNow/internal/model"
+)
+
+const (
+	AgendaDue     = "due"
+	AgendaPlanned = "planned"
+)
+
+// AgendaItem is one date of an open task: it is due or planned At. A task
+// with both dates has an item for each.
+type AgendaItem struct {
+	Index int
+	At    time.Time
+	Kind  string
+}
+
+type AgendaGroup struct {
+	Name  string
+	Items []AgendaItem
+}
+
+var agendaBuckets = []string{"Overdue", "Today", "Tomorrow", "This week", "Later"}
+
+// Agenda groups the due and planned dates of open tasks into Overdue,
+// Today, Tomorrow, This week (until Sunday) and Later, each by date. Past
+// due times and planned days before today are overdue. With days > 0,
+// items from that many days after today on are left out. Empty groups are
+// dropped.
+func Agenda(tasks []model.Task, now time.Time, days int) []AgendaGroup {
+	today := StartOfDay(now)
+	tomorrow := today.AddDate(0, 0, 1)
+	weekEnd := today.AddDate(0, 0, 7-(int(today.Weekday())+6)%7)
+	groups := make([]AgendaGroup, len(agendaBuckets))
+	for i, name := range agendaBuckets {
+		groups[i].Name = name
+	}
+	add := func(idx int, at time.Time, kind string) {
+		overdue := at.Before(now)
+		if kind == AgendaPlanned {
+			overdue = at.Before(today)
+		}
+		if days > 0 && !at.Before(today.AddDate(0, 0, days)) {
+			return
+		}
+		bucket := 4
+		switch {
+		case overdue:
+			bucket = 0
+		case at.Before(tomorrow):
+			bucket = 1
+		case at.Before(tomorrow.AddDate(0, 0, 1)):
+			bucket = 2
+		case at.Before(weekEnd):
+			bucket = 3
+		}
+		groups[bucket].Items = append(groups[bucket].Items, AgendaItem{Index: idx, At: at, Kind: kind})
+	}
+	for i, t := range tasks {
+		if t.IsDone() {
+			continue
+		}
+		if t.DueAt != nil {
+			add(i, *t.DueAt, AgendaDue)
+		}
+		if t.PlannedDate != nil {
+			add(i, *t.PlannedDate, AgendaPlanned)
+		}
+	}
+	var out []AgendaGroup
+	for _, g := range groups {
+		if len(g.Items) == 0 {
+			continue
+		}
+		sort.SliceStable(g.Items, func(i, j int) bool { return g.Items[i].At.Before(g.Items[j].At) })
+		out = append(out, g)
+	}
+	return out
+}
@@ -0,0 +1,48 @@
+package engine
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mrbooshehri/actNow/internal/model"
+)
+
+func TestAgenda(t *testing.T) {
+	// Wednesday, 10:30.
+	now := time.Date(2024, 3, 6, 10, 30, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time {
+		v := now.Add(d)
+		return &v
+	}
+	tasks := []model.Task{
+		{Title: "late", DueAt: at(-time.Hour)},
+		{Title: "noon", DueAt: at(90 * time.Minute), PlannedDate: at(-10 * time.Hour)},
+		{Title: "stale plan", PlannedDate: at(-24 * time.Hour)},
+		{Title: "thu", DueAt: at(24 * time.Hour)},
+		{Title: "sun", PlannedDate: at(4 * 24 * time.Hour)},
+		{Title: "next week", DueAt: at(6 * 24 * time.Hour)},
+		{Title: "done", DueAt: at(-time.Hour), Status: model.StatusDone},
+		{Title: "undated"},
+	}
+	describe := func(groups []AgendaGroup) string {
+		var parts []string
+		for _, g := range groups {
+			var items []string
+			for _, it := range g.Items {
+				items = append(items, tasks[it.Index].Title+"/"+it.Kind)
+			}
+			parts = append(parts, g.Name+": "+strings.Join(items, ","))
+		}
+		return strings.Join(parts, "; ")
+	}
+
+	want := "Overdue: stale plan/planned,late/due; Today: noon/planned,noon/due; Tomorrow: thu/due; This week: sun/planned; Later: next week/due"
+	if got := describe(Agenda(tasks, now, 0)); got != want {
+		t.Fatalf("unexpected agenda\n got: %s\nwant: %s", got, want)
+	}
+	want = "Overdue: stale plan/planned,late/due; Today: noon/planned,noon/due; Tomorrow: thu/due"
+	if got := describe(Agenda(tasks, now, 2)); got != want {
+		t.Fatalf("unexpected 2-day agenda\n got: %s\nwant: %s", got, want)
+	}
+}
@@ -0,0 +1,52 @@
+package engine
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mrbooshehri/actNow/internal/model"
+)
+
+// FollowUpOverdue reports whether t is an open delegated task whose
+// follow-up date has passed, so it should be escalated.
+func FollowUpOverdue(t model.Task, now time.Time) bool {
+	return strings.TrimSpace(t.DelegateTo) != "" && !t.IsDone() && t.FollowUpAt != nil && !t.FollowUpAt.After(now)
+}
+
+// OverdueFollowUps returns the indices of tasks needing escalation.
+func OverdueFollowUps(tasks []model.Task, now time.Time) []int {
+	var out []int
+	for i, t := range tasks {
+		if FollowUpOverdue(t, now) {
+			out = append(out, i)
+		}
+	}
+	return out
+}
+
+// WaitingFor groups the open delegated tasks by assignee. Within a group,
+// tasks are ordered by follow-up date, then due date; tasks without either
+// come last. assignee, when set, keeps only that person's tasks (case
+// insensitive).
+func WaitingFor(tasks []model.Task, assignee string) []Group {
+	var indices []int
+	for i, t := range tasks {
+		name := strings.TrimSpace(t.DelegateTo)
+		if name == "" || t.IsDone() {
+			continue
+		}
+		if assignee != "" && !strings.EqualFold(name, strings.TrimSpace(assignee)) {
+			continue
+		}
+		indices = append(indices, i)
+	}
+	sort.SliceStable(indices, func(i, j int) bool {
+		a, b := tasks[indices[i]], tasks[indices[j]]
+		if timeLess(a.FollowUpAt, b.FollowUpAt) || timeLess(b.FollowUpAt, a.FollowUpAt) {
+			return timeLess(a.FollowUpAt, b.FollowUpAt)
+		}
+		return timeLess(a.DueAt, b.DueAt)
+	})
+	return GroupTasks(tasks, indices, GroupDelegate)
+}
@@ -0,0 +1,49 @@
+package engine
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mrbooshehri/actNow/internal/model"
+)
+
+func TestWaitingFor(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time {
+		v := now.Add(d)
+		return &v
+	}
+	tasks := []model.Task{
+		{Title: "cert", DelegateTo: "alice", FollowUpAt: at(48 * time.Hour)},
+		{Title: "report", DelegateTo: "Bob", FollowUpAt: at(-time.Hour)},
+		{Title: "invoice", DelegateTo: "Alice", DueAt: at(time.Hour)},
+		{Title: "backup", DelegateTo: "alice", FollowUpAt: at(time.Hour)},
+		{Title: "closed", DelegateTo: "alice", Status: model.StatusDone, FollowUpAt: at(-time.Hour)},
+		{Title: "mine"},
+	}
+
+	groups := WaitingFor(tasks, "")
+	if len(groups) != 2 || groups[0].Name != "alice" || groups[1].Name != "Bob" {
+		t.Fatalf("expected alice and Bob groups, got %+v", groups)
+	}
+	if got := titles(tasks, groups[0].Indices); got != "backup,cert,invoice" {
+		t.Fatalf("expected follow-up order, got %s", got)
+	}
+	if groups := WaitingFor(tasks, "BOB"); len(groups) != 1 || len(groups[0].Indices) != 1 {
+		t.Fatalf("expected only Bob's task, got %+v", groups)
+	}
+
+	overdue := OverdueFollowUps(tasks, now)
+	if len(overdue) != 1 || overdue[0] != 1 {
+		t.Fatalf("expected only the open overdue follow-up, got %v", overdue)
+	}
+}
+
+func titles(tasks []model.Task, indices []int) string {
+	out := make([]string, len(indices))
+	for i, idx := range indices {
+		out[i] = tasks[idx].Title
+	}
+	return strings.Join(out, ",")
+}
@@ -0,0 +1,55 @@
+package engine
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// ParseDuration extends time.ParseDuration with d (days) and w (weeks)
+// units, e.g. "3d", "1w2d" or "1d12h".
+func ParseDuration(s string) (time.Duration, error) {
+	return parseDuration(s, 24*time.Hour, 7*24*time.Hour)
+}
+
+// parseDuration parses s with day and week as the lengths of "1d" and "1w".
+func parseDuration(s string, day, week time.Duration) (time.Duration, error) {
+	if s == "" {
+		return 0, fmt.Errorf("empty duration")
+	}
+	var total time.Duration
+	rest := s
+	for rest != "" {
+		i := 0
+		for i < len(rest) && rest[i] >= '0' && rest[i] <= '9' {
+			i++
+		}
+		if i == 0 {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+		n, err := strconv.Atoi(rest[:i])
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+		j := i
+		for j < len(rest) && (rest[j] < '0' || rest[j] > '9') {
+			j++
+		}
+		var unit time.Duration
+		switch rest[i:j] {
+		case "w":
+			unit = week
+		case "d":
+			unit = day
+		case "h":
+			unit = time.Hour
+		case "m":
+			unit = time.Minute
+		default:
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+		total += time.Duration(n) * unit
+		rest = rest[j:]
+	}
+	return total, nil
+}
@@ -0,0 +1,26 @@
+package engine
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDuration(t *testing.T) {
+	cases := map[string]time.Duration{
+		"30m":   30 * time.Minute,
+		"2h":    2 * time.Hour,
+		"1d12h": 36 * time.Hour,
+		"1w":    7 * 24 * time.Hour,
+	}
+	for in, want := range cases {
+		got, err := ParseDuration(in)
+		if err != nil || got != want {
+			t.Fatalf("%s: expected %v, got %v (%v)", in, want, got, err)
+		}
+	}
+	for _, in := range []string{"", "h", "3x", "1.5h"} {
+		if _, err := ParseDuration(in); err == nil {
+			t.Fatalf("%q: expected error", in)
+		}
+	}
+}
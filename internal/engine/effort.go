@@ -0,0 +1,99 @@
+package engine
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mrbooshehri/actNow/internal/model"
+)
+
+// WorkDay and WorkWeek are the lengths of "1d" and "1w" in an effort
+// estimate.
+const (
+	WorkDay  = 8 * time.Hour
+	WorkWeek = 5 * WorkDay
+)
+
+// DefaultStoryPoints maps story points to effort when the config has no
+// mapping of its own.
+var DefaultStoryPoints = map[string]string{
+	"1": "1h", "2": "2h", "3": "4h", "5": "1d", "8": "2d", "13": "1w",
+}
+
+// ParseEffort parses an effort estimate: a duration such as 30m, 2h, 1d or
+// 1h30m, or story points such as 3sp, 3pt or "3 points" looked up in
+// points (DefaultStoryPoints when nil).
+func ParseEffort(s string, points map[string]string) (time.Duration, error) {
+	s = strings.ToLower(strings.Join(strings.Fields(s), ""))
+	if s == "" {
+		return 0, fmt.Errorf("empty effort")
+	}
+	for _, suffix := range []string{"points", "point", "pts", "pt", "sp"} {
+		if n, ok := strings.CutSuffix(s, suffix); ok {
+			if _, err := strconv.Atoi(n); err != nil {
+				return 0, fmt.Errorf("invalid story points %q", s)
+			}
+			if points == nil {
+				points = DefaultStoryPoints
+			}
+			mapped, ok := points[n]
+			if !ok {
+				return 0, fmt.Errorf("no mapping for %s story points", n)
+			}
+			return parseDuration(mapped, WorkDay, WorkWeek)
+		}
+	}
+	d, err := parseDuration(s, WorkDay, WorkWeek)
+	if err != nil {
+		return 0, fmt.Errorf("invalid effort %q (want e.g. 30m, 2h, 1d, 1h30m or 3sp)", s)
+	}
+	return d, nil
+}
+
+// DayLoad is the planned effort for one day.
+type DayLoad struct {
+	Day         time.Time
+	Effort      time.Duration
+	Indices     []int
+	Unestimated int
+}
+
+// Overbooked reports whether the day's effort exceeds capacity.
+func (d DayLoad) Overbooked(capacity time.Duration) bool {
+	return capacity > 0 && d.Effort > capacity
+}
+
+// PlanCapacity sums the effort of open Important & Not Immediate tasks by
+// planned day, for days days starting on the day of from. Tasks whose
+// effort does not parse are counted as unestimated.
+func PlanCapacity(tasks []model.Task, from time.Time, days int, points map[string]string) []DayLoad {
+	start := StartOfDay(from)
+	loads := make([]DayLoad, days)
+	for i := range loads {
+		loads[i].Day = start.AddDate(0, 0, i)
+	}
+	for i, t := range tasks {
+		if QuadrantIndex(t) != 1 || t.IsDone() || t.PlannedDate == nil {
+			continue
+		}
+		day := StartOfDay(t.PlannedDate.In(start.Location()))
+		n := int(day.Sub(start).Hours()+12) / 24
+		if day.Before(start) || n >= days {
+			continue
+		}
+		loads[n].Indices = append(loads[n].Indices, i)
+		if effort, err := ParseEffort(t.EffortEstimate, points); err == nil {
+			loads[n].Effort += effort
+		} else {
+			loads[n].Unestimated++
+		}
+	}
+	for i := range loads {
+		indices := loads[i].Indices
+		sort.SliceStable(indices, func(a, b int) bool { return tasks[indices[a]].PlannedDate.Before(*tasks[indices[b]].PlannedDate) })
+	}
+	return loads
+}
@@ -0,0 +1,69 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mrbooshehri/actNow/internal/model"
+)
+
+func TestParseEffort(t *testing.T) {
+	cases := []struct {
+		in   string
+		want time.Duration
+	}{
+		{"30m", 30 * time.Minute},
+		{"2h", 2 * time.Hour},
+		{"1d", 8 * time.Hour},
+		{"1h30m", 90 * time.Minute},
+		{"1h 30m", 90 * time.Minute},
+		{"1w", 40 * time.Hour},
+		{"3sp", 4 * time.Hour},
+		{"5 points", 8 * time.Hour},
+	}
+	for _, tc := range cases {
+		got, err := ParseEffort(tc.in, nil)
+		if err != nil || got != tc.want {
+			t.Fatalf("ParseEffort(%q): expected %v, got %v (%v)", tc.in, tc.want, got, err)
+		}
+	}
+
+	if got, err := ParseEffort("2pt", map[string]string{"2": "3h"}); err != nil || got != 3*time.Hour {
+		t.Fatalf("expected custom story point mapping, got %v (%v)", got, err)
+	}
+	for _, bad := range []string{"", "soon", "4sp", "2x", "h"} {
+		if _, err := ParseEffort(bad, nil); err == nil {
+			t.Fatalf("ParseEffort(%q): expected an error", bad)
+		}
+	}
+}
+
+func TestPlanCapacity(t *testing.T) {
+	now := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+	day := func(n, hour int) *time.Time {
+		v := time.Date(2024, 3, 4+n, hour, 0, 0, 0, time.UTC)
+		return &v
+	}
+	tasks := []model.Task{
+		{Title: "a", Important: true, PlannedDate: day(0, 10), EffortEstimate: "4h"},
+		{Title: "b", Important: true, PlannedDate: day(0, 14), EffortEstimate: "3h"},
+		{Title: "c", Important: true, PlannedDate: day(1, 9), EffortEstimate: "someday"},
+		{Title: "d", Important: true, Urgent: true, PlannedDate: day(1, 9), EffortEstimate: "8h"},
+		{Title: "e", Important: true, PlannedDate: day(-1, 9), EffortEstimate: "1h"},
+		{Title: "f", Important: true, PlannedDate: day(2, 9), EffortEstimate: "1d", Status: model.StatusDone},
+	}
+
+	loads := PlanCapacity(tasks, now, 3, nil)
+	if len(loads) != 3 {
+		t.Fatalf("expected 3 days, got %d", len(loads))
+	}
+	if loads[0].Effort != 7*time.Hour || !loads[0].Overbooked(6*time.Hour) {
+		t.Fatalf("expected 7h overbooked on day one, got %+v", loads[0])
+	}
+	if loads[1].Effort != 0 || loads[1].Unestimated != 1 || len(loads[1].Indices) != 1 {
+		t.Fatalf("expected one unestimated task on day two, got %+v", loads[1])
+	}
+	if len(loads[2].Indices) != 0 {
+		t.Fatalf("expected done tasks to be ignored, got %+v", loads[2])
+	}
+}
@@ -0,0 +1,38 @@
+package engine
+
+import (
+	"time"
+
+	"github.com/mrbooshehri/actNow/internal/model"
+)
+
+// LastTouched returns when t last changed: its newest history event, or
+// its creation time when it has no history.
+func LastTouched(t model.Task) time.Time {
+	last := t.CreatedAt
+	for _, e := range t.History {
+		if e.At.After(last) {
+			last = e.At
+		}
+	}
+	return last
+}
+
+// StaleEliminations returns the open NI+NI tasks that have not been
+// touched for at least days days.
+func StaleEliminations(tasks []model.Task, days int, now time.Time) []int {
+	if days <= 0 {
+		return nil
+	}
+	cutoff := now.Add(-time.Duration(days) * 24 * time.Hour)
+	var out []int
+	for i, t := range tasks {
+		if QuadrantIndex(t) != 3 || t.IsDone() {
+			continue
+		}
+		if !LastTouched(t).After(cutoff) {
+			out = append(out, i)
+		}
+	}
+	return out
+}
@@ -0,0 +1,31 @@
+package engine
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/mrbooshehri/actNow/internal/model"
+)
+
+func TestStaleEliminations(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	old := now.Add(-40 * 24 * time.Hour)
+	tasks := []model.Task{
+		{Title: "old", CreatedAt: old},
+		{Title: "touched", CreatedAt: old, History: []model.Event{{At: now.Add(-2 * 24 * time.Hour), Note: "tags set"}}},
+		{Title: "important", Important: true, CreatedAt: old},
+		{Title: "done", Status: model.StatusDone, CreatedAt: old},
+		{Title: "fresh", CreatedAt: now.Add(-time.Hour)},
+	}
+
+	if got := StaleEliminations(tasks, 30, now); !reflect.DeepEqual(got, []int{0}) {
+		t.Fatalf("expected only the untouched NI+NI task, got %v", got)
+	}
+	if got := StaleEliminations(tasks, 0, now); got != nil {
+		t.Fatalf("expected auto-eliminate to be off for 0 days, got %v", got)
+	}
+	if got := LastTouched(tasks[1]); !got.Equal(now.Add(-2 * 24 * time.Hour)) {
+		t.Fatalf("expected last touch from history, got %v", got)
+	}
+}
@@ -0,0 +1,78 @@
+package engine
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/mrbooshehri/actNow/internal/model"
+)
+
+const (
+	GroupQuadrant = "quadrant"
+	GroupProject  = "project"
+	GroupDelegate = "delegate"
+)
+
+var Groupings = []string{GroupQuadrant, GroupProject, GroupDelegate}
+
+type Group struct {
+	Name    string
+	Indices []int
+}
+
+// GroupTasks splits indices into groups. Quadrant groups are returned in
+// quadrant order and always number four; project and delegate groups are
+// sorted by name with the unassigned group last.
+func GroupTasks(tasks []model.Task, indices []int, by string) []Group {
+	if by == GroupQuadrant || by == "" {
+		groups := []Group{
+			{Name: QuadrantImportantImmediate},
+			{Name: QuadrantImportantNotImmediate},
+			{Name: QuadrantNotImportantImmediate},
+			{Name: QuadrantNotImportantNot},
+		}
+		for _, idx := range indices {
+			q := QuadrantIndex(tasks[idx])
+			groups[q].Indices = append(groups[q].Indices, idx)
+		}
+		return groups
+	}
+
+	empty := "(no project)"
+	key := func(t model.Task) string { return strings.TrimSpace(t.Project) }
+	if by == GroupDelegate {
+		empty = "(not delegated)"
+		key = func(t model.Task) string { return strings.TrimSpace(t.DelegateTo) }
+	}
+
+	byName := map[string]*Group{}
+	var names []string
+	var unassigned *Group
+	for _, idx := range indices {
+		name := key(tasks[idx])
+		if name == "" {
+			if unassigned == nil {
+				unassigned = &Group{Name: empty}
+			}
+			unassigned.Indices = append(unassigned.Indices, idx)
+			continue
+		}
+		folded := strings.ToLower(name)
+		g, ok := byName[folded]
+		if !ok {
+			g = &Group{Name: name}
+			byName[folded] = g
+			names = append(names, folded)
+		}
+		g.Indices = append(g.Indices, idx)
+	}
+	sort.Strings(names)
+	groups := make([]Group, 0, len(names)+1)
+	for _, name := range names {
+		groups = append(groups, *byName[name])
+	}
+	if unassigned != nil {
+		groups = append(groups, *unassigned)
+	}
+	return groups
+}
@@ -0,0 +1,38 @@
+package engine
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mrbooshehri/actNow/internal/model"
+)
+
+func TestGroupTasks(t *testing.T) {
+	tasks := []model.Task{
+		{Title: "a", Important: true, Urgent: true, Project: "infra", DelegateTo: "bob"},
+		{Title: "b", Project: "Infra"},
+		{Title: "c", Urgent: true, DelegateTo: "alice"},
+		{Title: "d", Important: true, Project: "docs"},
+	}
+	all := []int{0, 1, 2, 3}
+
+	quadrants := GroupTasks(tasks, all, GroupQuadrant)
+	if len(quadrants) != 4 || !reflect.DeepEqual(quadrants[0].Indices, []int{0}) || !reflect.DeepEqual(quadrants[3].Indices, []int{1}) {
+		t.Fatalf("unexpected quadrant groups: %+v", quadrants)
+	}
+
+	projects := GroupTasks(tasks, all, GroupProject)
+	want := []Group{
+		{Name: "docs", Indices: []int{3}},
+		{Name: "infra", Indices: []int{0, 1}},
+		{Name: "(no project)", Indices: []int{2}},
+	}
+	if !reflect.DeepEqual(projects, want) {
+		t.Fatalf("expected %+v, got %+v", want, projects)
+	}
+
+	delegates := GroupTasks(tasks, all, GroupDelegate)
+	if len(delegates) != 3 || delegates[0].Name != "alice" || delegates[2].Name != "(not delegated)" {
+		t.Fatalf("unexpected delegate groups: %+v", delegates)
+	}
+}
@@ -1,6 +1,7 @@
 package engine
 
 import (
+	"strings"
 	"time"
 
 	"github.com/mrbooshehri/actNow/internal/model"
@@ -44,7 +45,41 @@ func ApplyUrgency(t model.Task, now time.Time) model.Task {
 		return t
 	}
 	if t.DueAt.Sub(now) <= 24*time.Hour {
+		t.DueUrgent = t.DueUrgent || !t.Urgent
 		t.Urgent = true
 	}
 	return t
 }
+
+// SetQuadrant moves t to quadrant q (0-3, in QuadrantIndex order) by
+// setting its importance and urgency.
+func SetQuadrant(t model.Task, q int) model.Task {
+	t.Important = q == 0 || q == 1
+	t.Urgent = q == 0 || q == 2
+	return t
+}
+
+// MissingFields lists the quadrant-specific fields of t that are empty.
+func MissingFields(t model.Task) []string {
+	var out []string
+	check := func(name string, empty bool) {
+		if empty {
+			out = append(out, name)
+		}
+	}
+	switch QuadrantIndex(t) {
+	case 0:
+		check("due", t.DueAt == nil)
+		check("impact", strings.TrimSpace(t.Impact) == "")
+		check("next action", strings.TrimSpace(t.NextAction) == "")
+	case 1:
+		check("planned date", t.PlannedDate == nil)
+		check("effort", strings.TrimSpace(t.EffortEstimate) == "")
+	case 2:
+		check("due", t.DueAt == nil)
+		check("delegate", strings.TrimSpace(t.DelegateTo) == "")
+	default:
+		check("delete reason", strings.TrimSpace(t.DeleteReason) == "")
+	}
+	return out
+}
@@ -1,6 +1,7 @@
 package engine
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -40,3 +41,26 @@ func TestApplyUrgency(t *testing.T) {
 		t.Fatalf("expected task to remain not urgent")
 	}
 }
+
+func TestSetQuadrantAndMissingFields(t *testing.T) {
+	task := model.Task{Important: true, Urgent: true, Impact: "Revenue"}
+	cases := []struct {
+		q    int
+		want string
+	}{
+		{q: 0, want: "due,next action"},
+		{q: 1, want: "planned date,effort"},
+		{q: 2, want: "due,delegate"},
+		{q: 3, want: "delete reason"},
+	}
+
+	for _, tc := range cases {
+		moved := SetQuadrant(task, tc.q)
+		if got := QuadrantIndex(moved); got != tc.q {
+			t.Fatalf("expected quadrant %d, got %d", tc.q, got)
+		}
+		if got := strings.Join(MissingFields(moved), ","); got != tc.want {
+			t.Fatalf("quadrant %d: expected missing %q, got %q", tc.q, tc.want, got)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package engine
+
+import (
+	"sort"
+	"time"
+
+	"github.com/mrbooshehri/actNow/internal/model"
+)
+
+// ReviewQueue returns the tasks to walk in a daily review: open pending
+// tasks, plus deferred tasks whose planned date has come. Overdue tasks
+// come first, then tasks by quadrant, each by due date.
+func ReviewQueue(tasks []model.Task, now time.Time) []int {
+	var out []int
+	for i, t := range tasks {
+		if t.Status == model.StatusPending || Resurfaced(t, now) {
+			out = append(out, i)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		a, b := tasks[out[i]], tasks[out[j]]
+		if oa, ob := Overdue(a, now), Overdue(b, now); oa != ob {
+			return oa
+		}
+		if qa, qb := QuadrantIndex(a), QuadrantIndex(b); qa != qb {
+			return qa < qb
+		}
+		return timeLess(a.DueAt, b.DueAt)
+	})
+	return out
+}
+
+// Resurfaced reports whether t was deferred to a planned date that has
+// now come.
+func Resurfaced(t model.Task, now time.Time) bool {
+	return t.Status == model.StatusDeferred && t.PlannedDate != nil && !StartOfDay(*t.PlannedDate).After(now)
+}
+
+// Overdue reports whether t is past its due date.
+func Overdue(t model.Task, now time.Time) bool {
+	return t.DueAt != nil && t.DueAt.Before(now)
+}
+
+// LastReview returns the most recent review of the given kind.
+func LastReview(reviews []model.Review, kind string) (model.Review, bool) {
+	var last model.Review
+	found := false
+	for _, r := range reviews {
+		if r.Kind == kind && (!found || r.At.After(last.At)) {
+			last, found = r, true
+		}
+	}
+	return last, found
+}
+
+// DailyReviewDone reports whether a daily review was completed today.
+func DailyReviewDone(reviews []model.Review, now time.Time) bool {
+	last, ok := LastReview(reviews, model.ReviewDaily)
+	return ok && !last.At.Before(StartOfDay(now))
+}
+
+// CompletedAt returns when t was last marked done.
+func CompletedAt(t model.Task) (time.Time, bool) {
+	if !t.IsDone() || t.DoneAt == nil {
+		return time.Time{}, false
+	}
+	return *t.DoneAt, true
+}
+
+// CompletedByQuadrant counts the tasks completed in [from, to) by
+// quadrant, in QuadrantIndex order.
+func CompletedByQuadrant(tasks []model.Task, from, to time.Time) [4]int {
+	var out [4]int
+	for _, t := range tasks {
+		if at, ok := CompletedAt(t); ok && !at.Before(from) && at.Before(to) {
+			out[QuadrantIndex(t)]++
+		}
+	}
+	return out
+}
+
+// UnplannedImportant returns the open Important & Not Immediate tasks
+// without a planned date, by due date.
+func UnplannedImportant(tasks []model.Task) []int {
+	var out []int
+	for i, t := range tasks {
+		if QuadrantIndex(t) == 1 && !t.IsDone() && t.PlannedDate == nil {
+			out = append(out, i)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return timeLess(tasks[out[i]].DueAt, tasks[out[j]].DueAt) })
+	return out
+}
+
+// StartOfDay returns midnight of t's day in t's location.
+func StartOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,77 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mrbooshehri/actNow/internal/model"
+)
+
+func TestReviewQueue(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time {
+		v := now.Add(d)
+		return &v
+	}
+	tasks := []model.Task{
+		{Title: "someday", Status: model.StatusPending},
+		{Title: "plan", Important: true, Status: model.StatusPending, DueAt: at(72 * time.Hour)},
+		{Title: "late", Status: model.StatusPending, DueAt: at(-time.Hour)},
+		{Title: "fire", Important: true, Urgent: true, Status: model.StatusPending},
+		{Title: "finished", Status: model.StatusDone},
+		{Title: "back today", Important: true, Status: model.StatusDeferred, PlannedDate: at(-time.Hour)},
+		{Title: "later", Important: true, Status: model.StatusDeferred, PlannedDate: at(48 * time.Hour)},
+		{Title: "parked", Status: model.StatusDeferred},
+	}
+	if got := titles(tasks, ReviewQueue(tasks, now)); got != "late,fire,plan,back today,someday" {
+		t.Fatalf("unexpected review order %s", got)
+	}
+}
+
+func TestDailyReviewDone(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	reviews := []model.Review{
+		{Kind: model.ReviewDaily, At: now.Add(-30 * time.Hour)},
+		{Kind: "weekly", At: now.Add(-time.Hour)},
+	}
+	if DailyReviewDone(reviews, now) {
+		t.Fatalf("expected yesterday's review not to count for today")
+	}
+	reviews = append(reviews, model.Review{Kind: model.ReviewDaily, At: now.Add(-11 * time.Hour)})
+	if !DailyReviewDone(reviews, now) {
+		t.Fatalf("expected this morning's review to count")
+	}
+	if last, ok := LastReview(reviews, model.ReviewDaily); !ok || !last.At.Equal(now.Add(-11*time.Hour)) {
+		t.Fatalf("expected the latest daily review, got %+v", last)
+	}
+}
+
+func TestCompletedByQuadrant(t *testing.T) {
+	now := time.Date(2024, 3, 8, 12, 0, 0, 0, time.UTC)
+	done := func(title string, important, urgent bool, ago time.Duration) model.Task {
+		at := now.Add(-ago)
+		return model.Task{Title: title, Important: important, Urgent: urgent, Status: model.StatusDone, DoneAt: &at}
+	}
+	reopened := now.Add(-time.Hour)
+	tasks := []model.Task{
+		done("a", true, true, 24*time.Hour),
+		done("b", true, false, 48*time.Hour),
+		done("c", true, false, 72*time.Hour),
+		done("old", false, false, 10*24*time.Hour),
+		{Title: "reopened", Status: model.StatusPending, DoneAt: &reopened},
+		{Title: "no timestamp", Status: model.StatusDone},
+	}
+	if got := CompletedByQuadrant(tasks, now.Add(-7*24*time.Hour), now); got != [4]int{1, 2, 0, 0} {
+		t.Fatalf("unexpected completions %v", got)
+	}
+
+	open := []model.Task{
+		{Title: "later", Important: true, DueAt: &now},
+		{Title: "planned", Important: true, PlannedDate: &now},
+		{Title: "first", Important: true},
+		{Title: "fire", Important: true, Urgent: true},
+	}
+	if got := titles(open, UnplannedImportant(open)); got != "later,first" {
+		t.Fatalf("unexpected unplanned tasks %s", got)
+	}
+}
@@ -0,0 +1,112 @@
+package engine
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mrbooshehri/actNow/internal/model"
+)
+
+const (
+	SortManual  = "manual"
+	SortDue     = "due"
+	SortPlanned = "planned"
+	SortCreated = "created"
+	SortEffort  = "effort"
+	SortTitle   = "title"
+)
+
+// SortOrders lists the sort strategies in the order the TUI cycles them.
+var SortOrders = []string{SortManual, SortDue, SortPlanned, SortCreated, SortEffort, SortTitle}
+
+// QuadrantKeys are short, stable names for the quadrants by index, used in
+// config files and filters.
+var QuadrantKeys = []string{"iim", "inim", "niim", "nini"}
+
+// NextSort returns the strategy after current in SortOrders.
+func NextSort(current string) string {
+	for i, s := range SortOrders {
+		if s == current {
+			return SortOrders[(i+1)%len(SortOrders)]
+		}
+	}
+	return SortOrders[1]
+}
+
+// NormalizeOrder gives every task without a manual order key one after
+// the current maximum, in slice order, so manual ordering is stable.
+func NormalizeOrder(tasks []model.Task) {
+	next := NextOrder(tasks)
+	for i := range tasks {
+		if tasks[i].Order == 0 {
+			tasks[i].Order = next
+			next++
+		}
+	}
+}
+
+// NextOrder returns an order key that places a new task last.
+func NextOrder(tasks []model.Task) int {
+	maxOrder := 0
+	for _, t := range tasks {
+		if t.Order > maxOrder {
+			maxOrder = t.Order
+		}
+	}
+	return maxOrder + 1
+}
+
+// SortIndices orders indices in place. Tasks without the sort key keep
+// their relative order after those that have it. Manual (or empty) sorts
+// by the task order key. Effort uses points to read story points. A pinned
+// task always comes first.
+func SortIndices(tasks []model.Task, indices []int, by string, points map[string]string) {
+	sortIndices(tasks, indices, by, points)
+	sort.SliceStable(indices, func(i, j int) bool {
+		return tasks[indices[i]].Pinned && !tasks[indices[j]].Pinned
+	})
+}
+
+func sortIndices(tasks []model.Task, indices []int, by string, points map[string]string) {
+	var less func(a, b model.Task) bool
+	switch by {
+	case SortDue:
+		less = func(a, b model.Task) bool { return timeLess(a.DueAt, b.DueAt) }
+	case SortPlanned:
+		less = func(a, b model.Task) bool { return timeLess(a.PlannedDate, b.PlannedDate) }
+	case SortCreated:
+		less = func(a, b model.Task) bool { return a.CreatedAt.Before(b.CreatedAt) }
+	case SortEffort:
+		less = func(a, b model.Task) bool {
+			ea, errA := ParseEffort(a.EffortEstimate, points)
+			eb, errB := ParseEffort(b.EffortEstimate, points)
+			switch {
+			case errB != nil:
+				return errA == nil
+			case errA != nil:
+				return false
+			default:
+				return ea < eb
+			}
+		}
+	case SortTitle:
+		less = func(a, b model.Task) bool { return strings.ToLower(a.Title) < strings.ToLower(b.Title) }
+	default:
+		less = func(a, b model.Task) bool { return a.Order < b.Order }
+	}
+	sort.SliceStable(indices, func(i, j int) bool {
+		return less(tasks[indices[i]], tasks[indices[j]])
+	})
+}
+
+func timeLess(a, b *time.Time) bool {
+	switch {
+	case a == nil:
+		return false
+	case b == nil:
+		return true
+	default:
+		return a.Before(*b)
+	}
+}
@@ -0,0 +1,81 @@
+package engine
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/mrbooshehri/actNow/internal/model"
+)
+
+func TestSortIndices(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	later := now.Add(time.Hour)
+	tasks := []model.Task{
+		{Title: "none", EffortEstimate: "a while", CreatedAt: later},
+		{Title: "later", DueAt: &later, PlannedDate: &now, EffortEstimate: "1d"},
+		{Title: "Now", DueAt: &now, EffortEstimate: "30m", CreatedAt: now},
+	}
+	cases := []struct {
+		by   string
+		want []int
+	}{
+		{SortManual, []int{0, 1, 2}},
+		{SortDue, []int{2, 1, 0}},
+		{SortPlanned, []int{1, 0, 2}},
+		{SortCreated, []int{1, 2, 0}},
+		{SortEffort, []int{2, 1, 0}},
+		{SortTitle, []int{1, 0, 2}},
+	}
+	for _, tc := range cases {
+		indices := []int{0, 1, 2}
+		SortIndices(tasks, indices, tc.by, nil)
+		if !reflect.DeepEqual(indices, tc.want) {
+			t.Fatalf("sort %s: expected %v, got %v", tc.by, tc.want, indices)
+		}
+	}
+}
+
+func TestSortEffortUsesPoints(t *testing.T) {
+	tasks := []model.Task{{EffortEstimate: "2h"}, {EffortEstimate: "3sp"}}
+	indices := []int{0, 1}
+	SortIndices(tasks, indices, SortEffort, map[string]string{"3": "1h"})
+	if !reflect.DeepEqual(indices, []int{1, 0}) {
+		t.Fatalf("expected the configured 3sp first, got %v", indices)
+	}
+}
+
+func TestManualOrderAndPin(t *testing.T) {
+	tasks := []model.Task{
+		{Title: "a", Order: 3},
+		{Title: "b"},
+		{Title: "c", Order: 1},
+		{Title: "d"},
+	}
+	NormalizeOrder(tasks)
+	if tasks[1].Order != 4 || tasks[3].Order != 5 {
+		t.Fatalf("expected new orders after the max, got %d and %d", tasks[1].Order, tasks[3].Order)
+	}
+
+	indices := []int{0, 1, 2, 3}
+	SortIndices(tasks, indices, SortManual, nil)
+	if !reflect.DeepEqual(indices, []int{2, 0, 1, 3}) {
+		t.Fatalf("expected manual order [2 0 1 3], got %v", indices)
+	}
+
+	tasks[3].Pinned = true
+	indices = []int{0, 1, 2, 3}
+	SortIndices(tasks, indices, SortTitle, nil)
+	if !reflect.DeepEqual(indices, []int{3, 0, 1, 2}) {
+		t.Fatalf("expected pinned task first, got %v", indices)
+	}
+}
+
+func TestNextSort(t *testing.T) {
+	if got := NextSort(SortManual); got != SortDue {
+		t.Fatalf("expected due after manual, got %s", got)
+	}
+	if got := NextSort(SortTitle); got != SortManual {
+		t.Fatalf("expected wrap to manual, got %s", got)
+	}
+}
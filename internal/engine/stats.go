@@ -0,0 +1,175 @@
+package engine
+
+import (
+	"time"
+
+	"github.com/mrbooshehri/actNow/internal/model"
+)
+
+// EscalatedNote is recorded in a task's history when ApplyUrgency makes it
+// urgent because its due date came within 24 hours.
+const EscalatedNote = "urgent: due within 24h"
+
+// Escalate records EscalatedNote on the Important tasks ApplyUrgency made
+// urgent, stamped when their due date came within 24 hours. Tasks created
+// that close to their due date, done before it, or already noted for that
+// due date are skipped. It is meant to run when tasks are saved.
+func Escalate(tasks []model.Task, now time.Time) {
+	for i := range tasks {
+		t := tasks[i]
+		if !t.Important || !t.DueUrgent || t.DueAt == nil {
+			continue
+		}
+		at := t.DueAt.Add(-24 * time.Hour)
+		if at.After(now) || at.Before(t.CreatedAt) {
+			continue
+		}
+		if done, ok := CompletedAt(t); t.IsDone() && (!ok || done.Before(at)) {
+			continue
+		}
+		pos := len(t.History)
+		noted := false
+		for j, e := range t.History {
+			if e.Note == EscalatedNote && e.At.Equal(at) {
+				noted = true
+			}
+			if pos == len(t.History) && e.At.After(at) {
+				pos = j
+			}
+		}
+		if noted {
+			continue
+		}
+		history := append([]model.Event{}, t.History[:pos]...)
+		history = append(history, model.Event{At: at, Note: EscalatedNote})
+		tasks[i].History = append(history, t.History[pos:]...)
+	}
+}
+
+// EscalatedAt returns when t was made urgent by Escalate.
+func EscalatedAt(t model.Task) (time.Time, bool) {
+	for i := len(t.History) - 1; i >= 0; i-- {
+		if t.History[i].Note == EscalatedNote {
+			return t.History[i].At, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// WeekRate is the share of tasks completed in a week, by quadrant, out of
+// those that were open at some point in it. A quadrant with no such tasks
+// has a rate of -1.
+type WeekRate struct {
+	Start time.Time
+	Rate  [4]float64
+}
+
+// Stats summarises the task list over the days before now. Quadrants are
+// in QuadrantIndex order.
+type Stats struct {
+	Days int
+	// Open and Done count tasks by quadrant and status, whenever created.
+	Open [4]int
+	Done [4]int
+	// Overdue counts open tasks past their due date.
+	Overdue [4]int
+	// Escalated counts Important tasks pushed into Important & Immediate
+	// by an approaching due date within the period.
+	Escalated int
+	// LeadTime is the average time from creation to completion of the
+	// tasks completed within the period, with Completed their number.
+	LeadTime  [4]time.Duration
+	Completed [4]int
+	// Weeks holds the completion rate of each seven days covering the
+	// period, oldest first; the last one ends now.
+	Weeks []WeekRate
+	// Daily counts completions per day, oldest first, ending today.
+	Daily []int
+}
+
+// TotalLeadTime is the average lead time across all quadrants.
+func (s Stats) TotalLeadTime() time.Duration {
+	var sum time.Duration
+	n := 0
+	for q := range s.LeadTime {
+		sum += s.LeadTime[q] * time.Duration(s.Completed[q])
+		n += s.Completed[q]
+	}
+	if n == 0 {
+		return 0
+	}
+	return sum / time.Duration(n)
+}
+
+// ComputeStats gathers Stats for the given number of days before now.
+func ComputeStats(tasks []model.Task, now time.Time, days int) Stats {
+	s := Stats{Days: days, Daily: make([]int, days)}
+	from := now.AddDate(0, 0, -days)
+	today := StartOfDay(now)
+	var lead [4]time.Duration
+
+	for _, t := range tasks {
+		q := QuadrantIndex(t)
+		switch t.Status {
+		case model.StatusDone:
+			s.Done[q]++
+		default:
+			s.Open[q]++
+			if t.Status == model.StatusPending && t.DueAt != nil && t.DueAt.Before(now) {
+				s.Overdue[q]++
+			}
+		}
+		if at, ok := EscalatedAt(t); ok && t.Important && !at.Before(from) {
+			s.Escalated++
+		}
+		at, ok := CompletedAt(t)
+		if !ok || at.Before(from) || at.After(now) {
+			continue
+		}
+		s.Completed[q]++
+		if at.After(t.CreatedAt) {
+			lead[q] += at.Sub(t.CreatedAt)
+		}
+		day := int(today.Sub(StartOfDay(at)).Hours()/24 + 0.5)
+		if day >= 0 && day < days {
+			s.Daily[days-1-day]++
+		}
+	}
+	for q := range lead {
+		if s.Completed[q] > 0 {
+			s.LeadTime[q] = lead[q] / time.Duration(s.Completed[q])
+		}
+	}
+
+	for i := 0; i < (days+6)/7; i++ {
+		end := now.AddDate(0, 0, -7*i)
+		start := end.AddDate(0, 0, -7)
+		var done, active [4]int
+		for _, t := range tasks {
+			if t.CreatedAt.After(end) {
+				continue
+			}
+			at, completed := CompletedAt(t)
+			if completed && at.Before(start) {
+				continue
+			}
+			if t.IsDone() && !completed {
+				continue
+			}
+			q := QuadrantIndex(t)
+			active[q]++
+			if completed && !at.After(end) {
+				done[q]++
+			}
+		}
+		w := WeekRate{Start: start}
+		for q := range w.Rate {
+			w.Rate[q] = -1
+			if active[q] > 0 {
+				w.Rate[q] = float64(done[q]) / float64(active[q])
+			}
+		}
+		s.Weeks = append([]WeekRate{w}, s.Weeks...)
+	}
+	return s
+}
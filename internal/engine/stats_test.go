@@ -0,0 +1,90 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mrbooshehri/actNow/internal/model"
+)
+
+func TestEscalate(t *testing.T) {
+	now := time.Date(2024, 3, 8, 9, 0, 0, 0, time.UTC)
+	created := now.AddDate(0, 0, -3)
+	due := now.Add(6 * time.Hour)
+	later := now.Add(72 * time.Hour)
+	edited := model.Event{At: now.Add(-time.Hour), Note: "title changed"}
+	tasks := []model.Task{
+		{Important: true, CreatedAt: created, DueAt: &due, History: []model.Event{edited}},
+		{Important: true, CreatedAt: created, DueAt: &later},
+		{Important: true, CreatedAt: now.Add(-time.Hour), DueAt: &due},
+		{CreatedAt: created, DueAt: &due},
+		{Important: true, Urgent: true, CreatedAt: created, DueAt: &due},
+	}
+	for i := range tasks {
+		tasks[i] = ApplyUrgency(tasks[i], now)
+	}
+
+	Escalate(tasks, now)
+	want := due.Add(-24 * time.Hour)
+	if at, ok := EscalatedAt(tasks[0]); !ok || !at.Equal(want) {
+		t.Fatalf("expected escalation recorded at %v, got %v %v", want, at, ok)
+	}
+	if tasks[0].History[0].Note != EscalatedNote || len(tasks[0].History) != 2 {
+		t.Fatalf("expected the note before the later edit, got %+v", tasks[0].History)
+	}
+	for i, task := range tasks[1:] {
+		if len(task.History) != 0 {
+			t.Fatalf("task %d: expected no escalation, got %+v", i+1, task.History)
+		}
+	}
+	Escalate(tasks, now.Add(time.Hour))
+	if len(tasks[0].History) != 2 {
+		t.Fatalf("expected no second note, got %+v", tasks[0].History)
+	}
+	if s := ComputeStats(tasks[4:], now, 7); s.Escalated != 0 {
+		t.Fatalf("expected a task urgent from the start not to count, got %d", s.Escalated)
+	}
+}
+
+func TestComputeStats(t *testing.T) {
+	now := time.Date(2024, 3, 8, 12, 0, 0, 0, time.UTC)
+	done := func(q int, created, completed time.Time) model.Task {
+		task := SetQuadrant(model.Task{Status: model.StatusDone, CreatedAt: created}, q)
+		task.DoneAt = &completed
+		return task
+	}
+	past := now.Add(-2 * time.Hour)
+	escalated := SetQuadrant(model.Task{Status: model.StatusPending, CreatedAt: now.AddDate(0, 0, -3), DueAt: &past}, 0)
+	escalated.History = []model.Event{{At: now.AddDate(0, 0, -1), Note: EscalatedNote}}
+	tasks := []model.Task{
+		done(0, now.AddDate(0, 0, -2), now.AddDate(0, 0, -1)),
+		done(0, now.AddDate(0, 0, -4), now.Add(-time.Hour)),
+		done(1, now.AddDate(0, 0, -20), now.AddDate(0, 0, -10)),
+		escalated,
+		SetQuadrant(model.Task{Status: model.StatusPending, CreatedAt: now.AddDate(0, 0, -1)}, 3),
+	}
+
+	s := ComputeStats(tasks, now, 14)
+	if s.Open != [4]int{1, 0, 0, 1} || s.Done != [4]int{2, 1, 0, 0} {
+		t.Fatalf("unexpected counts open=%v done=%v", s.Open, s.Done)
+	}
+	if s.Overdue != [4]int{1, 0, 0, 0} || s.Escalated != 1 {
+		t.Fatalf("unexpected overdue=%v escalated=%d", s.Overdue, s.Escalated)
+	}
+	if s.Completed != [4]int{2, 1, 0, 0} || s.LeadTime[0] != (24*time.Hour+4*24*time.Hour-time.Hour)/2 {
+		t.Fatalf("unexpected completed=%v lead=%v", s.Completed, s.LeadTime)
+	}
+	if len(s.Daily) != 14 || s.Daily[13] != 1 || s.Daily[12] != 1 || s.Daily[3] != 1 {
+		t.Fatalf("unexpected daily %v", s.Daily)
+	}
+	if len(s.Weeks) != 2 {
+		t.Fatalf("expected 2 weeks, got %d", len(s.Weeks))
+	}
+	last := s.Weeks[1]
+	if last.Rate[0] != 2.0/3 || last.Rate[1] != -1 || last.Rate[3] != 0 {
+		t.Fatalf("unexpected last week rates %v", last.Rate)
+	}
+	if s.Weeks[0].Rate[1] != 1 {
+		t.Fatalf("unexpected first week rates %v", s.Weeks[0].Rate)
+	}
+}
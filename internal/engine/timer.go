@@ -0,0 +1,86 @@
+package engine
+
+import (
+	"sort"
+	"time"
+
+	"github.com/mrbooshehri/actNow/internal/model"
+)
+
+// RunningTimer returns the index of the task with a running timer, or -1.
+func RunningTimer(tasks []model.Task) int {
+	for i, t := range tasks {
+		if t.Running() {
+			return i
+		}
+	}
+	return -1
+}
+
+// StartTimer starts the timer on tasks[i]. Only one timer runs at a time,
+// so any other running timer is stopped first; its index is returned, or
+// -1 when none was running.
+func StartTimer(tasks []model.Task, i int, now time.Time) int {
+	stopped := -1
+	for j := range tasks {
+		if j != i && tasks[j].Running() {
+			tasks[j].StopTimer(now)
+			stopped = j
+		}
+	}
+	tasks[i].StartTimer(now)
+	return stopped
+}
+
+// TimeRow compares the time spent on a task with its effort estimate.
+// Estimate is zero when the task has no valid estimate.
+type TimeRow struct {
+	Index    int
+	Spent    time.Duration
+	Estimate time.Duration
+	Running  bool
+}
+
+// Over reports whether more time went in than was estimated.
+func (r TimeRow) Over() bool {
+	return r.Estimate > 0 && r.Spent > r.Estimate
+}
+
+// TimeReport lists the tasks with time tracked between from and now, most
+// time first. Entries are clipped to that range; a zero from counts
+// everything. The estimate is always the whole task's.
+func TimeReport(tasks []model.Task, from, now time.Time, points map[string]string) []TimeRow {
+	var rows []TimeRow
+	for i, t := range tasks {
+		spent := spentSince(t, from, now)
+		if spent <= 0 && !t.Running() {
+			continue
+		}
+		row := TimeRow{Index: i, Spent: spent, Running: t.Running()}
+		if t.EffortEstimate != "" {
+			if d, err := ParseEffort(t.EffortEstimate, points); err == nil {
+				row.Estimate = d
+			}
+		}
+		rows = append(rows, row)
+	}
+	sort.SliceStable(rows, func(a, b int) bool { return rows[a].Spent > rows[b].Spent })
+	return rows
+}
+
+func spentSince(t model.Task, from, now time.Time) time.Duration {
+	var total time.Duration
+	for _, e := range t.TimeEntries {
+		start, end := e.Start, now
+		if e.End != nil {
+			end = *e.End
+		}
+		if start.Before(from) {
+			start = from
+		}
+		if end.After(start) {
+			total += end.Sub(start)
+		}
+	}
+	return total
+}
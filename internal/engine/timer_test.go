@@ -0,0 +1,62 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mrbooshehri/actNow/internal/model"
+)
+
+func TestStartTimerStopsOthers(t *testing.T) {
+	now := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+	tasks := []model.Task{{Title: "a"}, {Title: "b"}}
+
+	if stopped := StartTimer(tasks, 0, now); stopped != -1 {
+		t.Fatalf("expected nothing stopped, got %d", stopped)
+	}
+	if RunningTimer(tasks) != 0 {
+		t.Fatalf("expected task 0 running")
+	}
+	if stopped := StartTimer(tasks, 1, now.Add(30*time.Minute)); stopped != 0 {
+		t.Fatalf("expected task 0 stopped, got %d", stopped)
+	}
+	if RunningTimer(tasks) != 1 || tasks[0].Running() {
+		t.Fatalf("expected only task 1 running")
+	}
+	if got := tasks[0].TimeSpent(now.Add(time.Hour)); got != 30*time.Minute {
+		t.Fatalf("expected 30m on task 0, got %v", got)
+	}
+	if got := tasks[1].TimeSpent(now.Add(time.Hour)); got != 30*time.Minute {
+		t.Fatalf("expected 30m so far on task 1, got %v", got)
+	}
+}
+
+func TestTimeReport(t *testing.T) {
+	now := time.Date(2024, 3, 8, 12, 0, 0, 0, time.UTC)
+	at := func(d, h int) time.Time { return time.Date(2024, 3, d, h, 0, 0, 0, time.UTC) }
+	end := func(d, h int) *time.Time { t := at(d, h); return &t }
+	tasks := []model.Task{
+		{Title: "untracked", EffortEstimate: "1h"},
+		{Title: "over", EffortEstimate: "2h", TimeEntries: []model.TimeEntry{
+			{Start: at(4, 9), End: end(4, 11)},
+			{Start: at(7, 9), End: end(7, 10)},
+		}},
+		{Title: "running", EffortEstimate: "soon", TimeEntries: []model.TimeEntry{{Start: at(8, 11)}}},
+	}
+
+	rows := TimeReport(tasks, time.Time{}, now, nil)
+	if len(rows) != 2 || rows[0].Index != 1 || rows[1].Index != 2 {
+		t.Fatalf("unexpected rows %+v", rows)
+	}
+	if rows[0].Spent != 3*time.Hour || rows[0].Estimate != 2*time.Hour || !rows[0].Over() {
+		t.Fatalf("unexpected row %+v", rows[0])
+	}
+	if !rows[1].Running || rows[1].Estimate != 0 || rows[1].Over() || rows[1].Spent != time.Hour {
+		t.Fatalf("unexpected row %+v", rows[1])
+	}
+
+	rows = TimeReport(tasks, at(7, 9).Add(30*time.Minute), now, nil)
+	if len(rows) != 2 || rows[0].Spent != time.Hour || rows[1].Spent != 30*time.Minute {
+		t.Fatalf("expected clipped rows, got %+v", rows)
+	}
+}
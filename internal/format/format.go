@@ -0,0 +1,84 @@
+// Package format renders task data as text shared by the TUI and the
+// command line.
+package format
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// QuadrantLabels are short quadrant names, in engine.QuadrantIndex order.
+var QuadrantLabels = []string{"I+I", "I+NI", "NI+I", "NI+NI"}
+
+// QuadrantColor is the color of quadrant q.
+func QuadrantColor(q int) lipgloss.Color {
+	switch q {
+	case 0:
+		return lipgloss.Color("196")
+	case 1:
+		return lipgloss.Color("34")
+	case 2:
+		return lipgloss.Color("220")
+	default:
+		return lipgloss.Color("27")
+	}
+}
+
+// QuadrantMarker is a dot in the quadrant's color, for lists that mix
+// quadrants.
+func QuadrantMarker(q int) string {
+	return lipgloss.NewStyle().Foreground(QuadrantColor(q)).Render("●")
+}
+
+// AgendaTime formats an agenda item's time: the clock alone for today and
+// tomorrow, the day and clock otherwise.
+func AgendaTime(group string, at time.Time) string {
+	if group == "Today" || group == "Tomorrow" {
+		return at.Format("15:04")
+	}
+	return at.Format("Mon Jan _2 15:04")
+}
+
+// Duration formats d roughly, e.g. 45m, 2h30m or 3d4h.
+func Duration(d time.Duration) string {
+	if d < 0 {
+		d = -d
+	}
+	switch {
+	case d < time.Minute:
+		return "<1m"
+	case d < 24*time.Hour:
+		return Hours(d)
+	default:
+		days := int(d.Hours()) / 24
+		h := int(d.Hours()) - days*24
+		if h == 0 {
+			return fmt.Sprintf("%dd", days)
+		}
+		return fmt.Sprintf("%dd%dh", days, h)
+	}
+}
+
+// Hours formats an amount of work in hours and minutes, never days, e.g.
+// 45m, 12h or 1h30m.
+func Hours(d time.Duration) string {
+	h, mins := int(d.Hours()), int(d.Minutes())%60
+	switch {
+	case h == 0:
+		return fmt.Sprintf("%dm", mins)
+	case mins == 0:
+		return fmt.Sprintf("%dh", h)
+	default:
+		return fmt.Sprintf("%dh%dm", h, mins)
+	}
+}
+
+// Plural counts n things, e.g. "1 task" or "3 tasks".
+func Plural(n int, one, many string) string {
+	if n == 1 {
+		return "1 " + one
+	}
+	return fmt.Sprintf("%d %s", n, many)
+}
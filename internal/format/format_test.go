@@ -0,0 +1,56 @@
+package format
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDuration(t *testing.T) {
+	cases := map[time.Duration]string{
+		30 * time.Second:           "<1m",
+		45 * time.Minute:           "45m",
+		2 * time.Hour:              "2h",
+		150 * time.Minute:          "2h30m",
+		-3 * time.Hour:             "3h",
+		76 * time.Hour:             "3d4h",
+		48*time.Hour + time.Minute: "2d",
+	}
+	for d, want := range cases {
+		if got := Duration(d); got != want {
+			t.Errorf("Duration(%s) = %q, want %q", d, got, want)
+		}
+	}
+}
+
+func TestHours(t *testing.T) {
+	cases := map[time.Duration]string{
+		0:                "0m",
+		45 * time.Minute: "45m",
+		12 * time.Hour:   "12h",
+		90 * time.Minute: "1h30m",
+		40 * time.Hour:   "40h",
+	}
+	for d, want := range cases {
+		if got := Hours(d); got != want {
+			t.Errorf("Hours(%s) = %q, want %q", d, got, want)
+		}
+	}
+}
+
+func TestPlural(t *testing.T) {
+	if got := Plural(1, "task", "tasks"); got != "1 task" {
+		t.Errorf("Plural(1) = %q", got)
+	}
+	if got := Plural(0, "match", "matches"); got != "0 matches" {
+		t.Errorf("Plural(0) = %q", got)
+	}
+}
+
+func TestSparkline(t *testing.T) {
+	if got := Sparkline([]int{0, 1, 2, 4}); got != "▁▂▄█" {
+		t.Fatalf("unexpected sparkline %q", got)
+	}
+	if got := Sparkline([]int{0, 0}); got != "▁▁" {
+		t.Fatalf("expected a flat line without completions, got %q", got)
+	}
+}
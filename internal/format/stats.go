@@ -0,0 +1,80 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mrbooshehri/actNow/internal/engine"
+)
+
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline draws one block per count, scaled to the largest.
+func Sparkline(counts []int) string {
+	top := 0
+	for _, n := range counts {
+		top = max(top, n)
+	}
+	var b strings.Builder
+	for _, n := range counts {
+		if top == 0 {
+			b.WriteRune(sparkBlocks[0])
+			continue
+		}
+		b.WriteRune(sparkBlocks[n*(len(sparkBlocks)-1)/top])
+	}
+	return b.String()
+}
+
+// StatsLines renders s as plain text.
+func StatsLines(s engine.Stats) []string {
+	lines := []string{fmt.Sprintf("%-10s %6s %6s %8s", "Quadrant", "open", "done", "overdue")}
+	var open, done, overdue int
+	for q, label := range QuadrantLabels {
+		lines = append(lines, fmt.Sprintf("  %-8s %6d %6d %8d", label, s.Open[q], s.Done[q], s.Overdue[q]))
+		open += s.Open[q]
+		done += s.Done[q]
+		overdue += s.Overdue[q]
+	}
+	lines = append(lines, fmt.Sprintf("  %-8s %6d %6d %8d", "All", open, done, overdue))
+
+	lines = append(lines, "", fmt.Sprintf("Completion rate by week  %6s %6s %6s %6s", QuadrantLabels[0], QuadrantLabels[1], QuadrantLabels[2], QuadrantLabels[3]))
+	for _, w := range s.Weeks {
+		line := fmt.Sprintf("  from %-17s", w.Start.Format("Mon Jan _2"))
+		for _, r := range w.Rate {
+			if r < 0 {
+				line += fmt.Sprintf(" %6s", "-")
+				continue
+			}
+			line += fmt.Sprintf(" %5.0f%%", r*100)
+		}
+		lines = append(lines, line)
+	}
+
+	lines = append(lines, "", fmt.Sprintf("Lead time, created to done (last %d days)", s.Days))
+	for q, label := range QuadrantLabels {
+		lines = append(lines, "  "+leadTimeLine(label, s.LeadTime[q], s.Completed[q]))
+	}
+	total := 0
+	for _, n := range s.Completed {
+		total += n
+	}
+	lines = append(lines, "  "+leadTimeLine("All", s.TotalLeadTime(), total))
+
+	lines = append(lines, "", fmt.Sprintf("Escalated into I+I by a due date: %d", s.Escalated))
+	if s.Escalated > 0 {
+		lines = append(lines, "  Important work that became urgent for lack of planning; plan I+NI earlier.")
+	}
+	lines = append(lines, "",
+		fmt.Sprintf("Completions per day (last %d days): %d", s.Days, total),
+		"  "+Sparkline(s.Daily))
+	return lines
+}
+
+func leadTimeLine(label string, d time.Duration, n int) string {
+	if n == 0 {
+		return fmt.Sprintf("%-8s -", label)
+	}
+	return fmt.Sprintf("%-8s %-8s (%s)", label, Duration(d), Plural(n, "task", "tasks"))
+}
@@ -0,0 +1,19 @@
+package model
+
+import "time"
+
+const (
+	ReviewDaily  = "daily"
+	ReviewWeekly = "weekly"
+)
+
+// Review records a completed review: when it happened, how many tasks were
+// walked and what was decided, counted by outcome (e.g. "deferred": 2),
+// with an optional note.
+type Review struct {
+	Kind     string         `json:"kind"`
+	At       time.Time      `json:"at"`
+	Tasks    int            `json:"tasks"`
+	Outcomes map[string]int `json:"outcomes,omitempty"`
+	Note     string         `json:"note,omitempty"`
+}
@@ -3,6 +3,8 @@ package model
 import (
 	"crypto/rand"
 	"encoding/base32"
+	"fmt"
+	"slices"
 	"strings"
 	"time"
 )
@@ -14,27 +16,187 @@ const (
 )
 
 type Task struct {
-	ID             string     `json:"id"`
-	Title          string     `json:"title"`
-	Description    string     `json:"description"`
-	Important      bool       `json:"important"`
-	Urgent         bool       `json:"urgent"`
-	DueAt          *time.Time `json:"due_at,omitempty"`
-	Impact         string     `json:"impact,omitempty"`
-	NextAction     string     `json:"next_action,omitempty"`
-	PlannedDate    *time.Time `json:"planned_date,omitempty"`
-	DelegateTo     string     `json:"delegate_to,omitempty"`
-	DeleteReason   string     `json:"delete_reason,omitempty"`
-	EffortEstimate string     `json:"effort_estimate,omitempty"`
-	Status         string     `json:"status"`
-	CreatedAt      time.Time  `json:"created_at"`
+	ID             string      `json:"id"`
+	Title          string      `json:"title"`
+	Description    string      `json:"description"`
+	Important      bool        `json:"important"`
+	Urgent         bool        `json:"urgent"`
+	DueAt          *time.Time  `json:"due_at,omitempty"`
+	Impact         string      `json:"impact,omitempty"`
+	NextAction     string      `json:"next_action,omitempty"`
+	PlannedDate    *time.Time  `json:"planned_date,omitempty"`
+	DelegateTo     string      `json:"delegate_to,omitempty"`
+	FollowUpAt     *time.Time  `json:"follow_up_at,omitempty"`
+	DeleteReason   string      `json:"delete_reason,omitempty"`
+	EffortEstimate string      `json:"effort_estimate,omitempty"`
+	Tags           []string    `json:"tags,omitempty"`
+	Project        string      `json:"project,omitempty"`
+	Order          int         `json:"order,omitempty"`
+	Pinned         bool        `json:"pinned,omitempty"`
+	Status         string      `json:"status"`
+	CreatedAt      time.Time   `json:"created_at"`
+	DoneAt         *time.Time  `json:"done_at,omitempty"`
+	History        []Event     `json:"history,omitempty"`
+	TimeEntries    []TimeEntry `json:"time_entries,omitempty"`
+	// DueUrgent marks a task made urgent by its due date since it was
+	// loaded; it is not stored.
+	DueUrgent bool `json:"-"`
+}
+
+type Event struct {
+	At   time.Time `json:"at"`
+	Note string    `json:"note"`
+}
+
+// TrashEntry is a deleted task kept in the trash until it is purged.
+// Eliminated marks NI+NI tasks dropped through the elimination review.
+type TrashEntry struct {
+	Task       Task      `json:"task"`
+	DeletedAt  time.Time `json:"deleted_at"`
+	Reason     string    `json:"reason,omitempty"`
+	Eliminated bool      `json:"eliminated,omitempty"`
 }
 
 func (t Task) IsDone() bool {
 	return t.Status == StatusDone
 }
 
+func (t *Task) Record(at time.Time, note string) {
+	t.History = append(t.History, Event{At: at, Note: note})
+}
+
+// RecordChanges records the changes since before in t's history and keeps
+// DoneAt in step with its status.
+func (t *Task) RecordChanges(before Task, at time.Time) {
+	for _, change := range Changes(before, *t) {
+		t.Record(at, change)
+	}
+	switch {
+	case !t.IsDone():
+		t.DoneAt = nil
+	case !before.IsDone():
+		t.DoneAt = &at
+	}
+}
+
+// ParseTags splits a comma or space separated list of tags, dropping
+// leading '#' characters and duplicates.
+func ParseTags(s string) []string {
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	})
+	var tags []string
+	seen := map[string]bool{}
+	for _, f := range fields {
+		tag := strings.ToLower(strings.TrimLeft(f, "#"))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		tags = append(tags, tag)
+	}
+	return tags
+}
+
+func (t Task) HasTag(tag string) bool {
+	tag = strings.ToLower(strings.TrimLeft(tag, "#"))
+	for _, existing := range t.Tags {
+		if existing == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// EditTags applies a tag edit to tags: "+tag" adds, "-tag" removes, and
+// plain tags replace the whole list.
+func EditTags(tags []string, spec string) []string {
+	var add, remove, replace []string
+	for _, f := range strings.FieldsFunc(spec, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	}) {
+		switch f[0] {
+		case '+':
+			add = append(add, ParseTags(f[1:])...)
+		case '-':
+			remove = append(remove, ParseTags(f[1:])...)
+		default:
+			replace = append(replace, ParseTags(f)...)
+		}
+	}
+	out := tags
+	if len(replace) > 0 {
+		out = replace
+	}
+	out = ParseTags(strings.Join(append(append([]string{}, out...), add...), " "))
+	kept := out[:0]
+	for _, tag := range out {
+		if !slices.Contains(remove, tag) {
+			kept = append(kept, tag)
+		}
+	}
+	return kept
+}
+
+// Changes describes the user-visible differences between two versions of
+// a task, one line per changed field.
+func Changes(before, after Task) []string {
+	var out []string
+	text := func(label, a, b string) {
+		if a == b {
+			return
+		}
+		switch {
+		case a == "":
+			out = append(out, fmt.Sprintf("%s set to %q", label, b))
+		case b == "":
+			out = append(out, fmt.Sprintf("%s cleared", label))
+		default:
+			out = append(out, fmt.Sprintf("%s changed to %q", label, b))
+		}
+	}
+	date := func(label string, a, b *time.Time) {
+		switch {
+		case a == nil && b == nil:
+		case b == nil:
+			out = append(out, label+" cleared")
+		case a == nil || !a.Equal(*b):
+			out = append(out, label+" set to "+b.Format("2006-01-02 15:04"))
+		}
+	}
+
+	if before.Status != after.Status {
+		out = append(out, fmt.Sprintf("status %s → %s", before.Status, after.Status))
+	}
+	if before.Important != after.Important || before.Urgent != after.Urgent {
+		out = append(out, fmt.Sprintf("important=%t urgent=%t", after.Important, after.Urgent))
+	}
+	text("title", before.Title, after.Title)
+	if before.Description != after.Description {
+		out = append(out, "description edited")
+	}
+	date("due", before.DueAt, after.DueAt)
+	date("planned date", before.PlannedDate, after.PlannedDate)
+	text("impact", before.Impact, after.Impact)
+	text("next action", before.NextAction, after.NextAction)
+	text("effort", before.EffortEstimate, after.EffortEstimate)
+	text("delegate", before.DelegateTo, after.DelegateTo)
+	date("follow-up", before.FollowUpAt, after.FollowUpAt)
+	text("delete reason", before.DeleteReason, after.DeleteReason)
+	text("tags", strings.Join(before.Tags, ", "), strings.Join(after.Tags, ", "))
+	text("project", before.Project, after.Project)
+	if before.Pinned != after.Pinned {
+		if after.Pinned {
+			out = append(out, "pinned")
+		} else {
+			out = append(out, "unpinned")
+		}
+	}
+	return out
+}
+
 func NewTask(title, description string, important, urgent bool, dueAt *time.Time) Task {
+	now := time.Now()
 	return Task{
 		ID:          newID(),
 		Title:       title,
@@ -43,7 +205,8 @@ func NewTask(title, description string, important, urgent bool, dueAt *time.Time
 		Urgent:      urgent,
 		DueAt:       dueAt,
 		Status:      StatusPending,
-		CreatedAt:   time.Now(),
+		CreatedAt:   now,
+		History:     []Event{{At: now, Note: "created"}},
 	}
 }
 
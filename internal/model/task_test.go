@@ -0,0 +1,53 @@
+package model
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEditTags(t *testing.T) {
+	cases := []struct {
+		tags []string
+		spec string
+		want string
+	}{
+		{tags: []string{"ops"}, spec: "+infra", want: "ops,infra"},
+		{tags: []string{"ops", "infra"}, spec: "-ops", want: "infra"},
+		{tags: []string{"ops"}, spec: "+#Infra, -ops", want: "infra"},
+		{tags: []string{"ops"}, spec: "db security", want: "db,security"},
+		{tags: []string{"ops"}, spec: "db +ops -db", want: "ops"},
+		{tags: nil, spec: "-ops", want: ""},
+	}
+
+	for _, tc := range cases {
+		if got := strings.Join(EditTags(tc.tags, tc.spec), ","); got != tc.want {
+			t.Fatalf("EditTags(%v, %q): expected %q, got %q", tc.tags, tc.spec, tc.want, got)
+		}
+	}
+}
+
+func TestRecordChangesStampsDone(t *testing.T) {
+	at := time.Date(2024, 3, 8, 12, 0, 0, 0, time.UTC)
+	before := Task{Status: StatusPending}
+	task := before
+	task.Status = StatusDone
+	task.RecordChanges(before, at)
+	if task.DoneAt == nil || !task.DoneAt.Equal(at) || len(task.History) != 1 {
+		t.Fatalf("expected a done stamp and one history event, got %v %v", task.DoneAt, task.History)
+	}
+
+	edited := task
+	edited.Title = "renamed"
+	edited.RecordChanges(task, at.Add(time.Hour))
+	if !edited.DoneAt.Equal(at) {
+		t.Fatalf("expected an edit to keep the done stamp, got %v", edited.DoneAt)
+	}
+
+	reopened := edited
+	reopened.Status = StatusPending
+	reopened.RecordChanges(edited, at.Add(2*time.Hour))
+	if reopened.DoneAt != nil {
+		t.Fatalf("expected reopening to clear the done stamp, got %v", reopened.DoneAt)
+	}
+}
@@ -0,0 +1,51 @@
+package model
+
+import "time"
+
+// TimeEntry is a stretch of time spent on a task. A nil End means the
+// timer is still running.
+type TimeEntry struct {
+	Start time.Time  `json:"start"`
+	End   *time.Time `json:"end,omitempty"`
+}
+
+// Running reports whether the task has a running timer.
+func (t Task) Running() bool {
+	n := len(t.TimeEntries)
+	return n > 0 && t.TimeEntries[n-1].End == nil
+}
+
+// TimeSpent sums the task's time entries, counting a running one up to now.
+func (t Task) TimeSpent(now time.Time) time.Duration {
+	var total time.Duration
+	for _, e := range t.TimeEntries {
+		end := now
+		if e.End != nil {
+			end = *e.End
+		}
+		if end.After(e.Start) {
+			total += end.Sub(e.Start)
+		}
+	}
+	return total
+}
+
+// StartTimer opens a time entry at now unless one is already running.
+func (t *Task) StartTimer(now time.Time) {
+	if t.Running() {
+		return
+	}
+	t.TimeEntries = append(t.TimeEntries, TimeEntry{Start: now})
+}
+
+// StopTimer closes the running time entry at now and returns its length,
+// or zero when no timer was running.
+func (t *Task) StopTimer(now time.Time) time.Duration {
+	if !t.Running() {
+		return 0
+	}
+	e := &t.TimeEntries[len(t.TimeEntries)-1]
+	end := now
+	e.End = &end
+	return end.Sub(e.Start)
+}
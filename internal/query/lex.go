@@ -0,0 +1,96 @@
+package query
+
+import "strings"
+
+type tokenKind int
+
+const (
+	tokWord tokenKind = iota
+	tokString
+	tokNot
+	tokLParen
+	tokRParen
+)
+
+type token struct {
+	kind tokenKind
+	// text is the raw source of the token, value the same with quotes
+	// removed.
+	text  string
+	value string
+	pos   int
+}
+
+func lex(s string) ([]token, error) {
+	var tokens []token
+	i := 0
+	for i < len(s) {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n':
+			i++
+		case c == '(':
+			tokens = append(tokens, token{kind: tokLParen, text: "(", pos: i})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{kind: tokRParen, text: ")", pos: i})
+			i++
+		case c == '-' && i+1 < len(s) && !isBreak(s[i+1]):
+			tokens = append(tokens, token{kind: tokNot, text: "-", pos: i})
+			i++
+		case c == '"' || c == '\'':
+			value, n, err := readQuoted(s, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{kind: tokString, text: s[i : i+n], value: value, pos: i})
+			i += n
+		default:
+			start := i
+			var value strings.Builder
+			for i < len(s) && !isBreak(s[i]) {
+				if s[i] == '"' || s[i] == '\'' {
+					quoted, n, err := readQuoted(s, i)
+					if err != nil {
+						return nil, err
+					}
+					value.WriteString(quoted)
+					i += n
+					continue
+				}
+				value.WriteByte(s[i])
+				i++
+			}
+			tokens = append(tokens, token{kind: tokWord, text: s[start:i], value: value.String(), pos: start})
+		}
+	}
+	return tokens, nil
+}
+
+func isBreak(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '(' || c == ')'
+}
+
+// readQuoted returns the unquoted value at s[start] and its length.
+func readQuoted(s string, start int) (string, int, error) {
+	quote := s[start]
+	var b strings.Builder
+	i := start + 1
+	for i < len(s) {
+		switch s[i] {
+		case '\\':
+			if i+1 < len(s) {
+				b.WriteByte(s[i+1])
+				i += 2
+				continue
+			}
+			i++
+		case quote:
+			return b.String(), i + 1 - start, nil
+		default:
+			b.WriteByte(s[i])
+			i++
+		}
+	}
+	return "", 0, &Error{Pos: start, Msg: "unterminated quoted string"}
+}
@@ -0,0 +1,561 @@
+// Package query implements the task filter language used by the CLI, the
+// TUI search box and saved views.
+//
+// A query is a list of terms that must all match. Terms may be grouped
+// with parentheses, combined with "or", and negated with a leading "-" or
+// "not":
+//
+//	quadrant:iim status:pending due<48h tag:ops delegate:alice "db"
+//	(tag:ops or tag:infra) -status:done
+//
+// Bare words and quoted strings match title, description, impact, next
+// action, delegate and tags as case-insensitive substrings.
+package query
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mrbooshehri/actNow/internal/engine"
+	"github.com/mrbooshehri/actNow/internal/model"
+)
+
+type Error struct {
+	Pos int
+	Msg string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("column %d: %s", e.Pos+1, e.Msg)
+}
+
+type Query struct {
+	source string
+	root   node
+	text   func(model.Task, string) bool
+}
+
+// Parse compiles a filter expression. An empty expression matches every
+// task.
+func Parse(s string) (*Query, error) {
+	tokens, err := lex(s)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	q := &Query{source: s}
+	if len(tokens) == 0 {
+		return q, nil
+	}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if !p.done() {
+		tok := p.peek()
+		if tok.kind == tokRParen {
+			return nil, &Error{Pos: tok.pos, Msg: "unexpected \")\""}
+		}
+		return nil, &Error{Pos: tok.pos, Msg: fmt.Sprintf("unexpected %q", tok.text)}
+	}
+	q.root = root
+	return q, nil
+}
+
+func (q *Query) String() string {
+	if q == nil {
+		return ""
+	}
+	return q.source
+}
+
+// Empty reports whether the query has no terms.
+func (q *Query) Empty() bool {
+	return q == nil || q.root == nil
+}
+
+// WithText returns a copy of q that matches free-text terms with fn
+// instead of the default substring search.
+func (q *Query) WithText(fn func(t model.Task, term string) bool) *Query {
+	c := *q
+	c.text = fn
+	return &c
+}
+
+// TextTerms returns the free-text terms of the query, for highlighting.
+func (q *Query) TextTerms() []string {
+	if q == nil || q.root == nil {
+		return nil
+	}
+	var out []string
+	q.root.walk(func(n node) {
+		if t, ok := n.(*textNode); ok {
+			out = append(out, t.value)
+		}
+	})
+	return out
+}
+
+func (q *Query) Match(t model.Task, now time.Time) bool {
+	if q == nil || q.root == nil {
+		return true
+	}
+	text := q.text
+	if text == nil {
+		text = MatchText
+	}
+	return q.root.eval(&env{task: t, now: now, text: text})
+}
+
+// Filter returns the indices of tasks matching q.
+func (q *Query) Filter(tasks []model.Task, now time.Time) []int {
+	out := make([]int, 0, len(tasks))
+	for i, t := range tasks {
+		if q.Match(t, now) {
+			out = append(out, i)
+		}
+	}
+	return out
+}
+
+// MatchText is the default free-text matcher: a case-insensitive substring
+// search over the task's text fields.
+func MatchText(t model.Task, term string) bool {
+	term = strings.ToLower(term)
+	for _, field := range []string{t.Title, t.Description, t.Impact, t.NextAction, t.DelegateTo, strings.Join(t.Tags, " ")} {
+		if strings.Contains(strings.ToLower(field), term) {
+			return true
+		}
+	}
+	return false
+}
+
+type env struct {
+	task model.Task
+	now  time.Time
+	text func(model.Task, string) bool
+}
+
+type node interface {
+	eval(e *env) bool
+	walk(fn func(node))
+}
+
+type andNode struct{ left, right node }
+type orNode struct{ left, right node }
+type notNode struct{ inner node }
+type textNode struct{ value string }
+type predNode struct {
+	fn func(e *env) bool
+}
+
+func (n *andNode) eval(e *env) bool { return n.left.eval(e) && n.right.eval(e) }
+func (n *orNode) eval(e *env) bool  { return n.left.eval(e) || n.right.eval(e) }
+func (n *notNode) eval(e *env) bool { return !n.inner.eval(e) }
+func (n *textNode) eval(e *env) bool {
+	return e.text(e.task, n.value)
+}
+func (n *predNode) eval(e *env) bool { return n.fn(e) }
+
+func (n *andNode) walk(fn func(node)) { fn(n); n.left.walk(fn); n.right.walk(fn) }
+func (n *orNode) walk(fn func(node))  { fn(n); n.left.walk(fn); n.right.walk(fn) }
+func (n *notNode) walk(fn func(node)) { fn(n); n.inner.walk(fn) }
+func (n *textNode) walk(fn func(node)) {
+	fn(n)
+}
+func (n *predNode) walk(fn func(node)) { fn(n) }
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) done() bool  { return p.pos >= len(p.tokens) }
+func (p *parser) peek() token { return p.tokens[p.pos] }
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	p.pos++
+	return tok
+}
+
+func (p *parser) isKeyword(word string) bool {
+	if p.done() {
+		return false
+	}
+	tok := p.peek()
+	return tok.kind == tokWord && strings.EqualFold(tok.text, word)
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("or") {
+		tok := p.next()
+		if p.done() {
+			return nil, &Error{Pos: tok.pos, Msg: "\"or\" must be followed by a term"}
+		}
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &orNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for !p.done() && p.peek().kind != tokRParen && !p.isKeyword("or") {
+		if p.isKeyword("and") {
+			tok := p.next()
+			if p.done() {
+				return nil, &Error{Pos: tok.pos, Msg: "\"and\" must be followed by a term"}
+			}
+		}
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &andNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if p.done() {
+		pos := 0
+		if len(p.tokens) > 0 {
+			last := p.tokens[len(p.tokens)-1]
+			pos = last.pos + len(last.text)
+		}
+		return nil, &Error{Pos: pos, Msg: "expected a term"}
+	}
+	tok := p.peek()
+	if tok.kind == tokNot || (tok.kind == tokWord && strings.EqualFold(tok.text, "not")) {
+		p.next()
+		if p.done() {
+			return nil, &Error{Pos: tok.pos, Msg: "negation must be followed by a term"}
+		}
+		inner, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &notNode{inner: inner}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokLParen:
+		if !p.done() && p.peek().kind == tokRParen {
+			return nil, &Error{Pos: tok.pos, Msg: "empty group"}
+		}
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.done() || p.peek().kind != tokRParen {
+			return nil, &Error{Pos: tok.pos, Msg: "unclosed \"(\""}
+		}
+		p.next()
+		return inner, nil
+	case tokRParen:
+		return nil, &Error{Pos: tok.pos, Msg: "unexpected \")\""}
+	case tokString:
+		return &textNode{value: tok.value}, nil
+	default:
+		return parseTerm(tok)
+	}
+}
+
+var operators = []string{"<=", ">=", ":", "=", "<", ">"}
+
+func parseTerm(tok token) (node, error) {
+	keyEnd := 0
+	for keyEnd < len(tok.text) && isKeyChar(tok.text[keyEnd]) {
+		keyEnd++
+	}
+	op := ""
+	if keyEnd > 0 {
+		for _, candidate := range operators {
+			if strings.HasPrefix(tok.text[keyEnd:], candidate) {
+				op = candidate
+				break
+			}
+		}
+	}
+	if op == "" {
+		return &textNode{value: tok.value}, nil
+	}
+
+	key := strings.ToLower(tok.text[:keyEnd])
+	value := tok.value[keyEnd+len(op):]
+	valuePos := tok.pos + keyEnd + len(op)
+	f, ok := fields[key]
+	if !ok {
+		return nil, &Error{Pos: tok.pos, Msg: fmt.Sprintf("unknown field %q (known: %s)", key, strings.Join(fieldNames(), ", "))}
+	}
+	if value == "" {
+		return nil, &Error{Pos: valuePos, Msg: fmt.Sprintf("missing value for %s", key)}
+	}
+	if op == "=" {
+		op = ":"
+	}
+	if op != ":" && !f.ordered {
+		return nil, &Error{Pos: tok.pos + keyEnd, Msg: fmt.Sprintf("%s does not support %q; use %s:value", key, op, key)}
+	}
+	fn, err := f.build(op, value)
+	if err != nil {
+		return nil, &Error{Pos: valuePos, Msg: fmt.Sprintf("%s: %v", key, err)}
+	}
+	return &predNode{fn: fn}, nil
+}
+
+func isKeyChar(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c == '_'
+}
+
+type field struct {
+	ordered bool
+	build   func(op, value string) (func(e *env) bool, error)
+}
+
+var fields = map[string]field{
+	"quadrant":  {build: buildQuadrant},
+	"q":         {build: buildQuadrant},
+	"status":    {build: buildStatus},
+	"important": {build: buildBool(func(t model.Task) bool { return t.Important })},
+	"urgent":    {build: buildBool(func(t model.Task) bool { return t.Urgent })},
+	"tag":       {build: buildTag},
+	"delegate":  {build: buildText(func(t model.Task) string { return t.DelegateTo })},
+	"project":   {build: buildText(func(t model.Task) string { return t.Project })},
+	"title":     {build: buildText(func(t model.Task) string { return t.Title })},
+	"desc":      {build: buildText(func(t model.Task) string { return t.Description })},
+	"impact":    {build: buildText(func(t model.Task) string { return t.Impact })},
+	"next":      {build: buildText(func(t model.Task) string { return t.NextAction })},
+	"effort":    {build: buildText(func(t model.Task) string { return t.EffortEstimate })},
+	"id":        {build: buildID},
+	"due":       {ordered: true, build: buildDate(func(t model.Task) *time.Time { return t.DueAt }, false)},
+	"planned":   {ordered: true, build: buildDate(func(t model.Task) *time.Time { return t.PlannedDate }, false)},
+	"followup":  {ordered: true, build: buildDate(func(t model.Task) *time.Time { return t.FollowUpAt }, false)},
+	"created": {ordered: true, build: buildDate(func(t model.Task) *time.Time {
+		return &t.CreatedAt
+	}, true)},
+}
+
+func fieldNames() []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// QuadrantAliases maps the short quadrant names accepted by quadrant: to
+// engine quadrant indices.
+var QuadrantAliases = map[string]int{
+	engine.QuadrantKeys[0]: 0, "1": 0, "do": 0,
+	engine.QuadrantKeys[1]: 1, "2": 1, "plan": 1,
+	engine.QuadrantKeys[2]: 2, "3": 2, "delegate": 2,
+	engine.QuadrantKeys[3]: 3, "4": 3, "eliminate": 3,
+}
+
+func buildQuadrant(op, value string) (func(e *env) bool, error) {
+	q, ok := QuadrantAliases[strings.ToLower(value)]
+	if !ok {
+		return nil, fmt.Errorf("unknown quadrant %q (want iim, inim, niim, nini or 1-4)", value)
+	}
+	return func(e *env) bool { return engine.QuadrantIndex(e.task) == q }, nil
+}
+
+func buildStatus(op, value string) (func(e *env) bool, error) {
+	value = strings.ToLower(value)
+	switch value {
+	case model.StatusPending, model.StatusDone, model.StatusDeferred:
+		return func(e *env) bool { return statusOf(e.task) == value }, nil
+	case "open":
+		return func(e *env) bool { return statusOf(e.task) != model.StatusDone }, nil
+	default:
+		return nil, fmt.Errorf("unknown status %q (want pending, done, deferred or open)", value)
+	}
+}
+
+func statusOf(t model.Task) string {
+	if t.Status == "" {
+		return model.StatusPending
+	}
+	return t.Status
+}
+
+func buildBool(get func(model.Task) bool) func(op, value string) (func(e *env) bool, error) {
+	return func(op, value string) (func(e *env) bool, error) {
+		var want bool
+		switch strings.ToLower(value) {
+		case "yes", "true", "y", "1":
+			want = true
+		case "no", "false", "n", "0":
+			want = false
+		default:
+			return nil, fmt.Errorf("expected yes or no, got %q", value)
+		}
+		return func(e *env) bool { return get(e.task) == want }, nil
+	}
+}
+
+func buildTag(op, value string) (func(e *env) bool, error) {
+	switch strings.ToLower(value) {
+	case "none":
+		return func(e *env) bool { return len(e.task.Tags) == 0 }, nil
+	case "any":
+		return func(e *env) bool { return len(e.task.Tags) > 0 }, nil
+	}
+	return func(e *env) bool { return e.task.HasTag(value) }, nil
+}
+
+func buildText(get func(model.Task) string) func(op, value string) (func(e *env) bool, error) {
+	return func(op, value string) (func(e *env) bool, error) {
+		switch strings.ToLower(value) {
+		case "none":
+			return func(e *env) bool { return strings.TrimSpace(get(e.task)) == "" }, nil
+		case "any":
+			return func(e *env) bool { return strings.TrimSpace(get(e.task)) != "" }, nil
+		}
+		needle := strings.ToLower(value)
+		return func(e *env) bool { return strings.Contains(strings.ToLower(get(e.task)), needle) }, nil
+	}
+}
+
+func buildID(op, value string) (func(e *env) bool, error) {
+	prefix := strings.ToUpper(value)
+	return func(e *env) bool { return strings.HasPrefix(e.task.ID, prefix) }, nil
+}
+
+// buildDate reads a duration as an age for created, else as an offset from now.
+func buildDate(get func(model.Task) *time.Time, age bool) func(op, value string) (func(e *env) bool, error) {
+	return func(op, value string) (func(e *env) bool, error) {
+		lower := strings.ToLower(value)
+		if op == ":" {
+			switch lower {
+			case "none":
+				return func(e *env) bool { return get(e.task) == nil }, nil
+			case "any":
+				return func(e *env) bool { return get(e.task) != nil }, nil
+			case "overdue":
+				if age {
+					return nil, fmt.Errorf("overdue is only valid for due, planned and followup")
+				}
+				return func(e *env) bool {
+					d := get(e.task)
+					return d != nil && d.Before(e.now) && statusOf(e.task) != model.StatusDone
+				}, nil
+			case "today", "tomorrow", "week":
+				return func(e *env) bool {
+					d := get(e.task)
+					if d == nil {
+						return false
+					}
+					start, end := dayRange(lower, e.now)
+					return !d.Before(start) && d.Before(end)
+				}, nil
+			}
+			day, err := parseDay(value)
+			if err != nil {
+				return nil, fmt.Errorf("expected none, any, overdue, today, tomorrow, week or YYYY-MM-DD, got %q", value)
+			}
+			return func(e *env) bool {
+				d := get(e.task)
+				if d == nil {
+					return false
+				}
+				return !d.Before(day) && d.Before(day.AddDate(0, 0, 1))
+			}, nil
+		}
+
+		if dur, err := engine.ParseDuration(lower); err == nil {
+			return func(e *env) bool {
+				d := get(e.task)
+				if d == nil {
+					return false
+				}
+				if age {
+					return compare(e.now.Sub(*d), dur, op)
+				}
+				return compare(d.Sub(e.now), dur, op)
+			}, nil
+		}
+		if lower == "today" || lower == "tomorrow" {
+			return func(e *env) bool {
+				d := get(e.task)
+				if d == nil {
+					return false
+				}
+				start, _ := dayRange(lower, e.now)
+				return compareTime(*d, start, op)
+			}, nil
+		}
+		day, err := parseDay(value)
+		if err != nil {
+			return nil, fmt.Errorf("expected a duration like 48h, 3d or 1w, or a date YYYY-MM-DD, got %q", value)
+		}
+		return func(e *env) bool {
+			d := get(e.task)
+			if d == nil {
+				return false
+			}
+			return compareTime(*d, day, op)
+		}, nil
+	}
+}
+
+func compare(a, b time.Duration, op string) bool {
+	switch op {
+	case "<":
+		return a < b
+	case "<=":
+		return a <= b
+	case ">":
+		return a > b
+	default:
+		return a >= b
+	}
+}
+
+func compareTime(a, b time.Time, op string) bool {
+	switch op {
+	case "<":
+		return a.Before(b)
+	case "<=":
+		return !a.After(b)
+	case ">":
+		return a.After(b)
+	default:
+		return !a.Before(b)
+	}
+}
+
+func dayRange(name string, now time.Time) (time.Time, time.Time) {
+	y, m, d := now.Date()
+	today := time.Date(y, m, d, 0, 0, 0, 0, now.Location())
+	switch name {
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), today.AddDate(0, 0, 2)
+	case "week":
+		return today, today.AddDate(0, 0, 7)
+	default:
+		return today, today.AddDate(0, 0, 1)
+	}
+}
+
+func parseDay(value string) (time.Time, error) {
+	return time.ParseInLocation("2006-01-02", value, time.Local)
+}
@@ -0,0 +1,136 @@
+package query
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mrbooshehri/actNow/internal/model"
+)
+
+var now = time.Date(2025, 1, 6, 12, 0, 0, 0, time.Local)
+
+func at(d time.Duration) *time.Time {
+	t := now.Add(d)
+	return &t
+}
+
+func fixtures() []model.Task {
+	return []model.Task{
+		{ID: "AAA1", Title: "Fix prod outage", Important: true, Urgent: true, DueAt: at(5 * time.Hour), Impact: "Revenue loss", Tags: []string{"ops"}, Status: model.StatusPending, CreatedAt: now.Add(-2 * time.Hour)},
+		{ID: "BBB2", Title: "Write migration plan", Important: true, PlannedDate: at(72 * time.Hour), EffortEstimate: "4h", Status: model.StatusPending, CreatedAt: now.Add(-40 * 24 * time.Hour)},
+		{ID: "CCC3", Title: "Renew SSL cert", Urgent: true, DueAt: at(-3 * time.Hour), DelegateTo: "Alice Smith", FollowUpAt: at(-time.Hour), Tags: []string{"ops", "security"}, Status: model.StatusDeferred, CreatedAt: now.Add(-5 * 24 * time.Hour)},
+		{ID: "DDD4", Title: "Remove old test data", Description: "The db fixtures", DeleteReason: "Not needed", Status: model.StatusDone, CreatedAt: now.Add(-10 * 24 * time.Hour)},
+	}
+}
+
+func ids(tasks []model.Task, indices []int) string {
+	out := make([]string, len(indices))
+	for i, idx := range indices {
+		out[i] = tasks[idx].ID
+	}
+	return strings.Join(out, ",")
+}
+
+func TestMatch(t *testing.T) {
+	tasks := fixtures()
+	cases := []struct {
+		query string
+		want  string
+	}{
+		{"", "AAA1,BBB2,CCC3,DDD4"},
+		{"quadrant:iim", "AAA1"},
+		{"q:2", "BBB2"},
+		{"quadrant:niim", "CCC3"},
+		{"quadrant:eliminate", "DDD4"},
+		{"status:pending", "AAA1,BBB2"},
+		{"status:open", "AAA1,BBB2,CCC3"},
+		{"-status:done", "AAA1,BBB2,CCC3"},
+		{"not status:done", "AAA1,BBB2,CCC3"},
+		{"important:yes urgent:no", "BBB2"},
+		{"tag:ops", "AAA1,CCC3"},
+		{"tag:#security", "CCC3"},
+		{"tag:none", "BBB2,DDD4"},
+		{"delegate:alice", "CCC3"},
+		{`delegate:"alice smith"`, "CCC3"},
+		{"delegate:any", "CCC3"},
+		{"due<48h", "AAA1,CCC3"},
+		{"due>1h", "AAA1"},
+		{"due:overdue", "CCC3"},
+		{"due:none", "BBB2,DDD4"},
+		{"due:today", "AAA1,CCC3"},
+		{"due:2025-01-06", "AAA1,CCC3"},
+		{"planned<=3d", "BBB2"},
+		{"planned<2d", ""},
+		{"planned>2025-01-08", "BBB2"},
+		{"created<1d", "AAA1"},
+		{"created>=7d", "BBB2,DDD4"},
+		{"db", "DDD4"},
+		{`"prod outage"`, "AAA1"},
+		{"revenue", "AAA1"},
+		{"tag:ops or status:done", "AAA1,CCC3,DDD4"},
+		{"(tag:security or quadrant:iim) -due:overdue", "AAA1"},
+		{"tag:ops and urgent:yes", "AAA1,CCC3"},
+		{"id:bbb", "BBB2"},
+		{"effort:4h", "BBB2"},
+		{"followup:overdue", "CCC3"},
+		{"followup:none", "AAA1,BBB2,DDD4"},
+		{"quadrant:iim status:pending due<48h tag:ops", "AAA1"},
+	}
+
+	for _, tc := range cases {
+		q, err := Parse(tc.query)
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", tc.query, err)
+		}
+		if got := ids(tasks, q.Filter(tasks, now)); got != tc.want {
+			t.Fatalf("%q: expected [%s], got [%s]", tc.query, tc.want, got)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	cases := []struct {
+		query string
+		want  string
+	}{
+		{"colour:red", `column 1: unknown field "colour"`},
+		{"status:later", "column 8: status: unknown status"},
+		{"quadrant:5", "unknown quadrant"},
+		{"tag:", "column 5: missing value for tag"},
+		{"tag<ops", `column 4: tag does not support "<"`},
+		{"due<soon", "due: expected a duration"},
+		{"due:someday", "due: expected none, any"},
+		{"created:overdue", "only valid for due, planned and followup"},
+		{"important:maybe", "expected yes or no"},
+		{`"db`, "column 1: unterminated quoted string"},
+		{"(tag:ops", "column 1: unclosed"},
+		{"tag:ops)", `column 8: unexpected ")"`},
+		{"()", "empty group"},
+		{"tag:ops or", `"or" must be followed by a term`},
+		{"tag:ops and", `"and" must be followed by a term`},
+		{"not", "negation must be followed by a term"},
+	}
+
+	for _, tc := range cases {
+		_, err := Parse(tc.query)
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Fatalf("%q: expected error containing %q, got %v", tc.query, tc.want, err)
+		}
+	}
+}
+
+func TestTextTermsAndWithText(t *testing.T) {
+	q, err := Parse(`tag:ops "prod" -outage`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := strings.Join(q.TextTerms(), ","); got != "prod,outage" {
+		t.Fatalf("expected text terms prod,outage, got %s", got)
+	}
+
+	exact := q.WithText(func(task model.Task, term string) bool { return task.Title == term })
+	if exact.Match(fixtures()[0], now) {
+		t.Fatalf("expected custom text matcher to be used")
+	}
+}
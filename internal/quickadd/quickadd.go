@@ -0,0 +1,204 @@
+// Package quickadd reads and writes the one-line task syntax used for fast
+// capture:
+//
+//	Fix prod outage !imp !urg @due:+2h #ops >alice ~4h
+//
+// Words are the title, except for these markers:
+//
+//	!imp, !important    important
+//	!urg, !urgent       urgent
+//	@due:<date>         due date (any dateparse form; quote it to use spaces)
+//	@planned:<date>     planned date
+//	@project:<name>     project
+//	#tag                tag
+//	>name               delegate to
+//	~effort             effort estimate, e.g. ~4h or ~3sp
+//
+// A backslash escapes the next character; a word starting with one is kept
+// in the title, e.g. \#1. Double quotes group words, either a whole title
+// word ("#1 fan") or a marker value (@due:"tomorrow 9:00").
+package quickadd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mrbooshehri/actNow/internal/dateparse"
+	"github.com/mrbooshehri/actNow/internal/model"
+)
+
+// dateLayout writes local dates without spaces so they need no quotes.
+const dateLayout = "2006-01-02T15:04"
+
+// Parse reads s into a new task, resolving dates relative to now.
+func Parse(s string, now time.Time) (model.Task, error) {
+	words, err := split(s)
+	if err != nil {
+		return model.Task{}, err
+	}
+	var (
+		title []string
+		t     model.Task
+	)
+	for _, w := range words {
+		if w.literal {
+			if w.text != "" {
+				title = append(title, w.text)
+			}
+			continue
+		}
+		word := w.text
+		switch {
+		case word == "!imp" || word == "!important":
+			t.Important = true
+		case word == "!urg" || word == "!urgent":
+			t.Urgent = true
+		case strings.HasPrefix(word, "!") && len(word) > 1:
+			return model.Task{}, fmt.Errorf("unknown flag %q (want !imp or !urg)", word)
+		case strings.HasPrefix(word, "@") && len(word) > 1:
+			key, value, ok := strings.Cut(word[1:], ":")
+			if !ok || value == "" {
+				return model.Task{}, fmt.Errorf("%q: want @key:value", word)
+			}
+			if err := setField(&t, key, value, now); err != nil {
+				return model.Task{}, err
+			}
+		case strings.HasPrefix(word, "#") && len(word) > 1:
+			t.Tags = append(t.Tags, model.ParseTags(word)...)
+		case strings.HasPrefix(word, ">") && len(word) > 1:
+			t.DelegateTo = word[1:]
+		case strings.HasPrefix(word, "~") && len(word) > 1:
+			t.EffortEstimate = word[1:]
+		default:
+			title = append(title, word)
+		}
+	}
+	task := model.NewTask(strings.Join(title, " "), "", t.Important, t.Urgent, t.DueAt)
+	if task.Title == "" {
+		return model.Task{}, fmt.Errorf("a title is required")
+	}
+	task.PlannedDate = t.PlannedDate
+	task.Project = t.Project
+	task.Tags = model.ParseTags(strings.Join(t.Tags, " "))
+	task.DelegateTo = t.DelegateTo
+	task.EffortEstimate = t.EffortEstimate
+	return task, nil
+}
+
+func setField(t *model.Task, key, value string, now time.Time) error {
+	switch key {
+	case "due", "planned":
+		at, err := dateparse.Parse(value, now)
+		if err != nil {
+			return fmt.Errorf("@%s: %w", key, err)
+		}
+		if key == "due" {
+			t.DueAt = &at
+		} else {
+			t.PlannedDate = &at
+		}
+	case "project":
+		t.Project = value
+	default:
+		return fmt.Errorf("unknown field @%s (want due, planned or project)", key)
+	}
+	return nil
+}
+
+// word is literal, always part of the title, when quoted or escaped.
+type word struct {
+	text    string
+	literal bool
+}
+
+func split(s string) ([]word, error) {
+	var (
+		out     []word
+		cur     strings.Builder
+		inWord  bool
+		literal bool
+		inQuote bool
+		escaped bool
+	)
+	flush := func() {
+		if inWord {
+			out = append(out, word{text: cur.String(), literal: literal})
+		}
+		cur.Reset()
+		inWord, literal = false, false
+	}
+	for _, r := range s {
+		switch {
+		case escaped:
+			cur.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			literal = literal || !inWord
+			inWord, escaped = true, true
+		case r == '"':
+			literal = literal || !inWord
+			inWord, inQuote = true, !inQuote
+		case !inQuote && (r == ' ' || r == '\t'):
+			flush()
+		default:
+			cur.WriteRune(r)
+			inWord = true
+		}
+	}
+	if inQuote {
+		return nil, fmt.Errorf("unterminated quote")
+	}
+	if escaped {
+		cur.WriteRune('\\')
+	}
+	flush()
+	return out, nil
+}
+
+// Format writes t in the quick-add syntax, so that Parse(Format(t))
+// gives back its title, flags, dates, project, tags, delegate and effort.
+func Format(t model.Task) string {
+	var parts []string
+	for _, w := range strings.Fields(t.Title) {
+		w = escaper.Replace(w)
+		if len(w) > 1 && strings.ContainsRune("!@#>~", rune(w[0])) {
+			w = `\` + w
+		}
+		parts = append(parts, w)
+	}
+	if t.Important {
+		parts = append(parts, "!imp")
+	}
+	if t.Urgent {
+		parts = append(parts, "!urg")
+	}
+	if t.DueAt != nil {
+		parts = append(parts, "@due:"+t.DueAt.Local().Format(dateLayout))
+	}
+	if t.PlannedDate != nil {
+		parts = append(parts, "@planned:"+t.PlannedDate.Local().Format(dateLayout))
+	}
+	if t.Project != "" {
+		parts = append(parts, "@project:"+quote(t.Project))
+	}
+	for _, tag := range t.Tags {
+		parts = append(parts, "#"+tag)
+	}
+	if t.DelegateTo != "" {
+		parts = append(parts, ">"+quote(t.DelegateTo))
+	}
+	if t.EffortEstimate != "" {
+		parts = append(parts, "~"+quote(t.EffortEstimate))
+	}
+	return strings.Join(parts, " ")
+}
+
+var escaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+func quote(s string) string {
+	if strings.ContainsAny(s, " \t") {
+		return `"` + s + `"`
+	}
+	return s
+}
@@ -0,0 +1,75 @@
+package quickadd
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/mrbooshehri/actNow/internal/model"
+)
+
+func TestParse(t *testing.T) {
+	now := time.Date(2024, 3, 6, 10, 30, 0, 0, time.UTC)
+	task, err := Parse("Fix prod outage !imp !urg @due:+2h #ops >alice ~4h", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if task.Title != "Fix prod outage" || !task.Important || !task.Urgent {
+		t.Fatalf("unexpected title or flags: %+v", task)
+	}
+	if task.DueAt == nil || !task.DueAt.Equal(now.Add(2*time.Hour)) {
+		t.Fatalf("expected due in 2h, got %v", task.DueAt)
+	}
+	if !slices.Equal(task.Tags, []string{"ops"}) || task.DelegateTo != "alice" || task.EffortEstimate != "4h" {
+		t.Fatalf("unexpected tags, delegate or effort: %+v", task)
+	}
+	if task.ID == "" || task.Status != model.StatusPending {
+		t.Fatalf("expected a new pending task, got %+v", task)
+	}
+
+	task, err = Parse(`Review "#1 fan" mail \>quota @planned:"next mon 14:00" @project:infra #A #a`, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if task.Title != "Review #1 fan mail >quota" || task.Project != "infra" || !slices.Equal(task.Tags, []string{"a"}) {
+		t.Fatalf("unexpected literal words, project or tags: %+v", task)
+	}
+	if want := time.Date(2024, 3, 11, 14, 0, 0, 0, time.UTC); task.PlannedDate == nil || !task.PlannedDate.Equal(want) {
+		t.Fatalf("expected planned next Monday 14:00, got %v", task.PlannedDate)
+	}
+
+	for _, in := range []string{"", "!imp #ops", "x !soon", "x @due:someday", "x @when:fri", "x @due", `x "open`} {
+		if _, err := Parse(in, now); err == nil {
+			t.Errorf("Parse(%q): expected an error", in)
+		}
+	}
+}
+
+func TestFormatRoundTrip(t *testing.T) {
+	now := time.Date(2024, 3, 6, 10, 30, 0, 0, time.Local)
+	due := now.Add(26 * time.Hour)
+	tasks := []model.Task{
+		{Title: "Plain title"},
+		{Title: "Fix prod outage", Important: true, Urgent: true, DueAt: &due, Tags: []string{"ops", "db"}, DelegateTo: "alice", EffortEstimate: "4h"},
+		{Title: `Ping #1 >boss about "the" C:\path ~now`, PlannedDate: &due, Project: "big move", DelegateTo: "Bob Smith", EffortEstimate: "1h 30m"},
+	}
+	for _, want := range tasks {
+		line := Format(want)
+		got, err := Parse(line, now)
+		if err != nil {
+			t.Fatalf("Parse(Format(%q)) = %q: %v", want.Title, line, err)
+		}
+		if got.Title != want.Title || got.Important != want.Important || got.Urgent != want.Urgent ||
+			!sameTime(got.DueAt, want.DueAt) || !sameTime(got.PlannedDate, want.PlannedDate) || got.Project != want.Project ||
+			!slices.Equal(got.Tags, want.Tags) || got.DelegateTo != want.DelegateTo || got.EffortEstimate != want.EffortEstimate {
+			t.Errorf("round trip of %q through %q gave %+v", want.Title, line, got)
+		}
+	}
+	if got := Format(tasks[1]); got != "Fix prod outage !imp !urg @due:2024-03-07T12:30 #ops #db >alice ~4h" {
+		t.Errorf("unexpected format %q", got)
+	}
+}
+
+func sameTime(a, b *time.Time) bool {
+	return a == nil && b == nil || a != nil && b != nil && a.Equal(*b)
+}
@@ -9,6 +9,8 @@ import (
 
 const dataDirName = ".actnow"
 const dataFileName = "tasks.json"
+const trashFileName = "trash.json"
+const reviewsFileName = "reviews.json"
 
 var ErrCorruptData = errors.New("stored tasks are corrupted")
 
@@ -29,8 +31,46 @@ func (s *Store) Path() string {
 	return s.path
 }
 
+func (s *Store) Dir() string {
+	return filepath.Dir(s.path)
+}
+
+func (s *Store) TrashPath() string {
+	return filepath.Join(s.Dir(), trashFileName)
+}
+
+func (s *Store) ReviewsPath() string {
+	return filepath.Join(s.Dir(), reviewsFileName)
+}
+
 func (s *Store) Load() ([]byte, error) {
-	b, err := os.ReadFile(s.path)
+	return readList(s.path)
+}
+
+func (s *Store) Save(data []byte) error {
+	return writeFile(s.path, data)
+}
+
+// LoadTrash reads the deleted tasks kept in trash.json.
+func (s *Store) LoadTrash() ([]byte, error) {
+	return readList(s.TrashPath())
+}
+
+func (s *Store) SaveTrash(data []byte) error {
+	return writeFile(s.TrashPath(), data)
+}
+
+// LoadReviews reads the log of completed reviews kept in reviews.json.
+func (s *Store) LoadReviews() ([]byte, error) {
+	return readList(s.ReviewsPath())
+}
+
+func (s *Store) SaveReviews(data []byte) error {
+	return writeFile(s.ReviewsPath(), data)
+}
+
+func readList(path string) ([]byte, error) {
+	b, err := os.ReadFile(path)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return []byte("[]"), nil
@@ -40,11 +80,11 @@ func (s *Store) Load() ([]byte, error) {
 	return b, nil
 }
 
-func (s *Store) Save(data []byte) error {
-	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+func writeFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
 		return err
 	}
-	return os.WriteFile(s.path, data, 0o600)
+	return os.WriteFile(path, data, 0o600)
 }
 
 func DecodeTasks(data []byte, v any) error {
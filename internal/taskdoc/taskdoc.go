@@ -0,0 +1,294 @@
+package taskdoc
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mrbooshehri/actNow/internal/model"
+)
+
+const (
+	delimiter  = "---"
+	dateLayout = "2006-01-02 15:04"
+)
+
+var keys = []string{
+	"id",
+	"title",
+	"status",
+	"important",
+	"urgent",
+	"due",
+	"impact",
+	"next_action",
+	"planned",
+	"effort",
+	"delegate_to",
+	"follow_up",
+	"delete_reason",
+	"tags",
+	"project",
+}
+
+type Error struct {
+	Line int
+	Msg  string
+}
+
+func (e *Error) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("line %d: %s", e.Line, e.Msg)
+	}
+	return e.Msg
+}
+
+func Marshal(t model.Task) []byte {
+	var b bytes.Buffer
+	b.WriteString(delimiter + "\n")
+	for _, key := range keys {
+		fmt.Fprintf(&b, "%s: %s\n", key, fieldValue(t, key))
+	}
+	b.WriteString(delimiter + "\n\n")
+	if t.Description != "" {
+		b.WriteString(t.Description)
+		b.WriteString("\n")
+	}
+	return b.Bytes()
+}
+
+func fieldValue(t model.Task, key string) string {
+	switch key {
+	case "id":
+		return t.ID
+	case "title":
+		return t.Title
+	case "status":
+		return t.Status
+	case "important":
+		return strconv.FormatBool(t.Important)
+	case "urgent":
+		return strconv.FormatBool(t.Urgent)
+	case "due":
+		return formatDate(t.DueAt)
+	case "impact":
+		return t.Impact
+	case "next_action":
+		return t.NextAction
+	case "planned":
+		return formatDate(t.PlannedDate)
+	case "effort":
+		return t.EffortEstimate
+	case "delegate_to":
+		return t.DelegateTo
+	case "follow_up":
+		return formatDate(t.FollowUpAt)
+	case "delete_reason":
+		return t.DeleteReason
+	case "tags":
+		return strings.Join(t.Tags, ", ")
+	case "project":
+		return t.Project
+	default:
+		return ""
+	}
+}
+
+func formatDate(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Local().Format(dateLayout)
+}
+
+// Apply parses a document produced by Marshal and returns base with the
+// edited fields applied. The task ID cannot be changed.
+func Apply(base model.Task, data []byte) (model.Task, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	line := 0
+
+	for scanner.Scan() {
+		line++
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		if strings.TrimSpace(scanner.Text()) != delimiter {
+			return base, &Error{Line: line, Msg: "expected front matter to start with ---"}
+		}
+		break
+	}
+	if line == 0 {
+		return base, &Error{Msg: "document is empty"}
+	}
+
+	t := base
+	seen := map[string]bool{}
+	closed := false
+	for scanner.Scan() {
+		line++
+		text := scanner.Text()
+		if strings.TrimSpace(text) == delimiter {
+			closed = true
+			break
+		}
+		if strings.TrimSpace(text) == "" || strings.HasPrefix(strings.TrimSpace(text), "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(text, ":")
+		if !ok {
+			return base, &Error{Line: line, Msg: fmt.Sprintf("expected \"key: value\", got %q", text)}
+		}
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+		if seen[key] {
+			return base, &Error{Line: line, Msg: fmt.Sprintf("duplicate key %q", key)}
+		}
+		seen[key] = true
+		if err := setField(&t, base, key, value); err != nil {
+			return base, &Error{Line: line, Msg: err.Error()}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return base, err
+	}
+	if !closed {
+		return base, &Error{Line: line, Msg: "front matter is not closed with ---"}
+	}
+
+	var body []string
+	for scanner.Scan() {
+		body = append(body, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return base, err
+	}
+	t.Description = strings.TrimSpace(strings.Join(body, "\n"))
+
+	if t.Title == "" {
+		return base, &Error{Msg: "title is required"}
+	}
+	return t, nil
+}
+
+func setField(t *model.Task, base model.Task, key, value string) error {
+	switch key {
+	case "id":
+		if value != base.ID {
+			return fmt.Errorf("id cannot be changed (was %s)", base.ID)
+		}
+	case "title":
+		t.Title = value
+	case "status":
+		switch value {
+		case model.StatusPending, model.StatusDone, model.StatusDeferred:
+			t.Status = value
+		case "":
+			t.Status = model.StatusPending
+		default:
+			return fmt.Errorf("unknown status %q (want %s, %s or %s)", value, model.StatusPending, model.StatusDone, model.StatusDeferred)
+		}
+	case "important", "urgent":
+		b, err := parseBool(value)
+		if err != nil {
+			return fmt.Errorf("%s: %v", key, err)
+		}
+		if key == "important" {
+			t.Important = b
+		} else {
+			t.Urgent = b
+		}
+	case "due", "planned", "follow_up":
+		field := &t.FollowUpAt
+		switch key {
+		case "due":
+			field = &t.DueAt
+		case "planned":
+			field = &t.PlannedDate
+		}
+		// An unedited date keeps the stored time, seconds included.
+		if value == formatDate(*field) {
+			break
+		}
+		d, err := parseDate(value)
+		if err != nil {
+			return fmt.Errorf("%s: %v", key, err)
+		}
+		*field = d
+	case "impact":
+		t.Impact = value
+	case "next_action":
+		t.NextAction = value
+	case "effort":
+		t.EffortEstimate = value
+	case "delegate_to":
+		t.DelegateTo = value
+	case "delete_reason":
+		t.DeleteReason = value
+	case "tags":
+		t.Tags = model.ParseTags(value)
+	case "project":
+		t.Project = value
+	default:
+		return fmt.Errorf("unknown key %q", key)
+	}
+	return nil
+}
+
+func parseBool(value string) (bool, error) {
+	switch strings.ToLower(value) {
+	case "true", "yes", "y":
+		return true, nil
+	case "false", "no", "n", "":
+		return false, nil
+	default:
+		return false, fmt.Errorf("expected true or false, got %q", value)
+	}
+}
+
+func parseDate(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	d, err := time.ParseInLocation(dateLayout, value, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("expected YYYY-MM-DD HH:MM, got %q", value)
+	}
+	return &d, nil
+}
+
+// Command returns a command that opens path in $VISUAL or $EDITOR,
+// falling back to vi.
+func Command(path string) *exec.Cmd {
+	parts := strings.Fields(os.Getenv("VISUAL"))
+	if len(parts) == 0 {
+		parts = strings.Fields(os.Getenv("EDITOR"))
+	}
+	if len(parts) == 0 {
+		parts = []string{"vi"}
+	}
+	args := append(parts[1:], path)
+	return exec.Command(parts[0], args...)
+}
+
+func WriteTemp(t model.Task) (string, error) {
+	f, err := os.CreateTemp("", "actnow-*.md")
+	if err != nil {
+		return "", err
+	}
+	if _, err := f.Write(Marshal(t)); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
@@ -0,0 +1,129 @@
+package taskdoc
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mrbooshehri/actNow/internal/model"
+)
+
+func TestRoundTrip(t *testing.T) {
+	due := time.Date(2025, 1, 5, 13, 0, 0, 0, time.Local)
+	task := model.Task{
+		ID:          "ABC",
+		Title:       "Fix prod outage",
+		Description: "Line one\n\nLine two",
+		Important:   true,
+		Urgent:      true,
+		DueAt:       &due,
+		Impact:      "Revenue loss",
+		NextAction:  "Restart DB",
+		Status:      model.StatusPending,
+	}
+
+	got, err := Apply(task, Marshal(task))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Title != task.Title || got.Description != task.Description || got.Impact != task.Impact {
+		t.Fatalf("round trip mismatch: %+v", got)
+	}
+	if got.DueAt == nil || !got.DueAt.Equal(due) {
+		t.Fatalf("expected due %v, got %v", due, got.DueAt)
+	}
+	if got.PlannedDate != nil {
+		t.Fatalf("expected no planned date, got %v", got.PlannedDate)
+	}
+}
+
+func TestRoundTripKeepsDates(t *testing.T) {
+	// Stored in another zone and with seconds, which the document drops.
+	due := time.Date(2025, 1, 5, 13, 0, 30, 0, time.FixedZone("X", 5*3600+1800))
+	task := model.Task{ID: "ABC", Title: "Renew cert", DueAt: &due, Status: model.StatusPending}
+
+	got, err := Apply(task, Marshal(task))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if changes := model.Changes(task, got); len(changes) != 0 {
+		t.Fatalf("expected no changes, got %v", changes)
+	}
+	if !strings.Contains(string(Marshal(task)), due.Local().Format(dateLayout)) {
+		t.Fatalf("expected the due date in local time, got %s", Marshal(task))
+	}
+}
+
+func TestApplyEdits(t *testing.T) {
+	base := model.Task{ID: "ABC", Title: "Old", Status: model.StatusPending}
+	doc := strings.Join([]string{
+		"---",
+		"id: ABC",
+		"title: New title",
+		"status: done",
+		"important: yes",
+		"planned: 2025-01-12 09:00",
+		"---",
+		"",
+		"Notes here",
+	}, "\n")
+
+	got, err := Apply(base, []byte(doc))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Title != "New title" || got.Status != model.StatusDone || !got.Important {
+		t.Fatalf("edits not applied: %+v", got)
+	}
+	if got.PlannedDate == nil || got.PlannedDate.Day() != 12 {
+		t.Fatalf("expected planned date, got %v", got.PlannedDate)
+	}
+	if got.Description != "Notes here" {
+		t.Fatalf("expected description, got %q", got.Description)
+	}
+}
+
+func TestApplyErrors(t *testing.T) {
+	base := model.Task{ID: "ABC", Title: "Old"}
+	cases := []struct {
+		doc  string
+		want string
+	}{
+		{doc: "", want: "document is empty"},
+		{doc: "title: x\n", want: "line 1: expected front matter"},
+		{doc: "---\ntitle: x\n", want: "not closed"},
+		{doc: "---\nid: XYZ\n---\n", want: "line 2: id cannot be changed"},
+		{doc: "---\ncolor: red\n---\n", want: "unknown key \"color\""},
+		{doc: "---\nstatus: later\n---\n", want: "unknown status"},
+		{doc: "---\nurgent: maybe\n---\n", want: "urgent: expected true or false"},
+		{doc: "---\ndue: tomorrow\n---\n", want: "due: expected YYYY-MM-DD HH:MM"},
+		{doc: "---\ntitle:\n---\n", want: "title is required"},
+		{doc: "---\ntitle: a\ntitle: b\n---\n", want: "duplicate key"},
+	}
+
+	for _, tc := range cases {
+		_, err := Apply(base, []byte(tc.doc))
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Fatalf("doc %q: expected error containing %q, got %v", tc.doc, tc.want, err)
+		}
+	}
+}
+
+func TestCommand(t *testing.T) {
+	cases := []struct {
+		visual, editor string
+		want           []string
+	}{
+		{"", "", []string{"vi", "x.md"}},
+		{"  ", "\t", []string{"vi", "x.md"}},
+		{" ", "code --wait", []string{"code", "--wait", "x.md"}},
+		{"nvim", "nano", []string{"nvim", "x.md"}},
+	}
+	for _, c := range cases {
+		t.Setenv("VISUAL", c.visual)
+		t.Setenv("EDITOR", c.editor)
+		if got := Command("x.md").Args; strings.Join(got, " ") != strings.Join(c.want, " ") {
+			t.Errorf("VISUAL=%q EDITOR=%q: got %q, want %q", c.visual, c.editor, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package taskmd
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/mrbooshehri/actNow/internal/engine"
+	"github.com/mrbooshehri/actNow/internal/model"
+)
+
+var (
+	headingRe  = regexp.MustCompile(`^#{1,6}\s+(.*?)\s*#*\s*$`)
+	checkboxRe = regexp.MustCompile(`^(\s*)[-*+] \[([ xX-])\](?:\s+(.*))?$`)
+	idRe       = regexp.MustCompile(`\s*<!--\s*id:\s*(\S+?)\s*-->\s*$`)
+	subRe      = regexp.MustCompile(`^\s+[-*+]\s+([^:]+):\s*(.*)$`)
+	quoteRe    = regexp.MustCompile(`^\s*>\s?(.*)$`)
+)
+
+// Item is a task read from a Markdown document. Quadrant is -1 for a
+// checkbox outside the quadrant sections. Fields maps the labels of the
+// sub-bullets present to their values, so an import only changes what the
+// document mentions.
+type Item struct {
+	Line           int
+	ID             string
+	Title          string
+	Status         string
+	Quadrant       int
+	Fields         map[string]string
+	Description    string
+	HasDescription bool
+}
+
+type Error struct {
+	Line int
+	Msg  string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Msg)
+}
+
+// Parse reads the checkbox items of a document written by Marshal, or of
+// any Markdown checkbox list. Sections named after a quadrant put their
+// items in it; other headings end the quadrant. Sub-bullets with an
+// unknown label and other text are ignored.
+func Parse(data []byte) ([]Item, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	var items []Item
+	var current *Item
+	var desc []string
+	quadrant := -1
+	line := 0
+
+	finish := func() {
+		if current == nil {
+			return
+		}
+		for len(desc) > 0 && strings.TrimSpace(desc[len(desc)-1]) == "" {
+			desc = desc[:len(desc)-1]
+		}
+		if len(desc) > 0 {
+			current.Description = strings.Join(desc, "\n")
+			current.HasDescription = true
+		}
+		items = append(items, *current)
+		current, desc = nil, nil
+	}
+
+	for scanner.Scan() {
+		line++
+		text := scanner.Text()
+		if m := headingRe.FindStringSubmatch(text); m != nil {
+			finish()
+			quadrant = quadrantIndex(m[1])
+			continue
+		}
+		if m := checkboxRe.FindStringSubmatch(text); m != nil {
+			finish()
+			item := Item{Line: line, Quadrant: quadrant, Fields: map[string]string{}, Status: status(m[2])}
+			title := m[3]
+			if id := idRe.FindStringSubmatch(title); id != nil {
+				item.ID = id[1]
+				title = title[:len(title)-len(id[0])]
+			}
+			item.Title = oneLine(title)
+			if item.Title == "" {
+				return nil, &Error{Line: line, Msg: "task without a title"}
+			}
+			current = &item
+			continue
+		}
+		if current == nil {
+			continue
+		}
+		if m := quoteRe.FindStringSubmatch(text); m != nil {
+			desc = append(desc, m[1])
+			continue
+		}
+		if m := subRe.FindStringSubmatch(text); m != nil && len(desc) == 0 {
+			if label, ok := fieldLabel(m[1]); ok {
+				current.Fields[label] = strings.TrimSpace(m[2])
+			}
+			continue
+		}
+		if strings.TrimSpace(text) == "" || strings.HasPrefix(text, " ") || strings.HasPrefix(text, "\t") {
+			continue
+		}
+		finish()
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	finish()
+	return items, nil
+}
+
+// Apply returns base with the item's title, status, quadrant, fields and
+// description applied; fields the item does not mention are kept. A
+// checkbox outside the quadrant sections keeps the task's quadrant.
+func Apply(base model.Task, it Item, now time.Time) (model.Task, error) {
+	t := base
+	t.Title = it.Title
+	t.Status = it.Status
+	if it.Quadrant >= 0 {
+		t = engine.SetQuadrant(t, it.Quadrant)
+	}
+	for _, f := range fields {
+		value, ok := it.Fields[f.label]
+		if !ok || value == oneLine(f.get(t)) {
+			continue
+		}
+		if err := f.set(&t, value, now); err != nil {
+			return base, &Error{Line: it.Line, Msg: fmt.Sprintf("%s: %v", f.label, err)}
+		}
+	}
+	if it.HasDescription {
+		t.Description = it.Description
+	}
+	return t, nil
+}
+
+func quadrantIndex(heading string) int {
+	for q, name := range Quadrants {
+		if strings.EqualFold(strings.TrimSpace(heading), name) {
+			return q
+		}
+	}
+	return -1
+}
+
+func fieldLabel(s string) (string, bool) {
+	for _, f := range fields {
+		if strings.EqualFold(strings.TrimSpace(s), f.label) {
+			return f.label, true
+		}
+	}
+	return "", false
+}
+
+func status(mark string) string {
+	switch mark {
+	case "x", "X":
+		return model.StatusDone
+	case "-":
+		return model.StatusDeferred
+	default:
+		return model.StatusPending
+	}
+}
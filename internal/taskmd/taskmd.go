@@ -0,0 +1,164 @@
+// Package taskmd writes tasks as a Markdown checklist, one section per
+// quadrant, with each task's fields as sub-bullets, and reads such
+// documents, or plain checkbox lists, back.
+package taskmd
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mrbooshehri/actNow/internal/dateparse"
+	"github.com/mrbooshehri/actNow/internal/engine"
+	"github.com/mrbooshehri/actNow/internal/model"
+)
+
+const dateLayout = "2006-01-02 15:04"
+
+// Quadrants are the section headings, in QuadrantIndex order.
+var Quadrants = []string{
+	engine.QuadrantImportantImmediate,
+	engine.QuadrantImportantNotImmediate,
+	engine.QuadrantNotImportantImmediate,
+	engine.QuadrantNotImportantNot,
+}
+
+type field struct {
+	label string
+	get   func(t model.Task) string
+	set   func(t *model.Task, value string, now time.Time) error
+}
+
+var fields = []field{
+	{"Due", func(t model.Task) string { return formatDate(t.DueAt) }, dateSetter(func(t *model.Task) **time.Time { return &t.DueAt })},
+	{"Impact", func(t model.Task) string { return t.Impact }, textSetter(func(t *model.Task) *string { return &t.Impact })},
+	{"Next action", func(t model.Task) string { return t.NextAction }, textSetter(func(t *model.Task) *string { return &t.NextAction })},
+	{"Planned", func(t model.Task) string { return formatDate(t.PlannedDate) }, dateSetter(func(t *model.Task) **time.Time { return &t.PlannedDate })},
+	{"Effort", func(t model.Task) string { return t.EffortEstimate }, textSetter(func(t *model.Task) *string { return &t.EffortEstimate })},
+	{"Delegate to", func(t model.Task) string { return t.DelegateTo }, textSetter(func(t *model.Task) *string { return &t.DelegateTo })},
+	{"Follow-up", func(t model.Task) string { return formatDate(t.FollowUpAt) }, dateSetter(func(t *model.Task) **time.Time { return &t.FollowUpAt })},
+	{"Delete reason", func(t model.Task) string { return t.DeleteReason }, textSetter(func(t *model.Task) *string { return &t.DeleteReason })},
+	{"Tags", func(t model.Task) string {
+		if len(t.Tags) == 0 {
+			return ""
+		}
+		return "#" + strings.Join(t.Tags, " #")
+	}, func(t *model.Task, value string, _ time.Time) error {
+		t.Tags = model.ParseTags(value)
+		return nil
+	}},
+	{"Project", func(t model.Task) string { return t.Project }, textSetter(func(t *model.Task) *string { return &t.Project })},
+}
+
+func textSetter(ptr func(t *model.Task) *string) func(t *model.Task, value string, now time.Time) error {
+	return func(t *model.Task, value string, _ time.Time) error {
+		*ptr(t) = value
+		return nil
+	}
+}
+
+func dateSetter(ptr func(t *model.Task) **time.Time) func(t *model.Task, value string, now time.Time) error {
+	return func(t *model.Task, value string, now time.Time) error {
+		if value == "" {
+			*ptr(t) = nil
+			return nil
+		}
+		at, err := dateparse.Parse(value, now)
+		if err != nil {
+			return err
+		}
+		*ptr(t) = &at
+		return nil
+	}
+}
+
+// quadrantFields are written first under a task in that quadrant.
+var quadrantFields = [][]string{
+	{"Due", "Impact", "Next action"},
+	{"Planned", "Effort"},
+	{"Due", "Delegate to", "Follow-up"},
+	{"Delete reason"},
+}
+
+// Mark is the checkbox for a task's status, as in the task list: [x] done,
+// [-] deferred and [ ] pending.
+func Mark(status string) string {
+	switch status {
+	case model.StatusDone:
+		return "[x]"
+	case model.StatusDeferred:
+		return "[-]"
+	default:
+		return "[ ]"
+	}
+}
+
+// Marshal renders tasks, in the given order within each quadrant, as a
+// Markdown document. Every quadrant gets a section, even an empty one.
+// Task IDs are kept in HTML comments so the document can be imported back.
+func Marshal(tasks []model.Task, now time.Time) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "# actNow tasks\n\n_Exported %s._\n", now.Format(dateLayout))
+	for q, heading := range Quadrants {
+		fmt.Fprintf(&b, "\n## %s\n\n", heading)
+		n := 0
+		for _, t := range tasks {
+			if engine.QuadrantIndex(t) != q {
+				continue
+			}
+			writeTask(&b, t, q)
+			n++
+		}
+		if n == 0 {
+			b.WriteString("_No tasks._\n")
+		}
+	}
+	return b.Bytes()
+}
+
+func writeTask(b *bytes.Buffer, t model.Task, q int) {
+	fmt.Fprintf(b, "- %s %s", Mark(t.Status), oneLine(t.Title))
+	if t.ID != "" {
+		fmt.Fprintf(b, " <!-- id:%s -->", t.ID)
+	}
+	b.WriteString("\n")
+	written := map[string]bool{}
+	write := func(f field) {
+		if written[f.label] {
+			return
+		}
+		written[f.label] = true
+		if v := f.get(t); v != "" {
+			fmt.Fprintf(b, "  - %s: %s\n", f.label, oneLine(v))
+		}
+	}
+	for _, label := range quadrantFields[q] {
+		for _, f := range fields {
+			if f.label == label {
+				write(f)
+			}
+		}
+	}
+	for _, f := range fields {
+		write(f)
+	}
+	if desc := strings.TrimSpace(t.Description); desc != "" {
+		b.WriteString("\n")
+		for _, line := range strings.Split(desc, "\n") {
+			b.WriteString(strings.TrimRight("  > "+line, " ") + "\n")
+		}
+		b.WriteString("\n")
+	}
+}
+
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func formatDate(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Local().Format(dateLayout)
+}
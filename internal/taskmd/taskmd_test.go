@@ -0,0 +1,170 @@
+package taskmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mrbooshehri/actNow/internal/model"
+)
+
+func TestMarshal(t *testing.T) {
+	now := time.Date(2025, 1, 5, 9, 0, 0, 0, time.Local)
+	due := time.Date(2025, 1, 5, 13, 0, 0, 0, time.Local)
+	tasks := []model.Task{
+		{ID: "AAA", Title: "Fix prod outage", Important: true, Urgent: true, DueAt: &due,
+			Impact: "Revenue loss", Tags: []string{"ops", "db"}, Description: "Line one\n\nLine two", Status: model.StatusPending},
+		{ID: "BBB", Title: "Renew cert", Urgent: true, DelegateTo: "alice", EffortEstimate: "1h", Status: model.StatusDeferred},
+		{ID: "CCC", Title: "Old  data", Status: model.StatusDone, DeleteReason: "nobody reads it"},
+	}
+
+	want := `# actNow tasks
+
+_Exported 2025-01-05 09:00._
+
+## Important & Immediate
+
+- [ ] Fix prod outage <!-- id:AAA -->
+  - Due: 2025-01-05 13:00
+  - Impact: Revenue loss
+  - Tags: #ops #db
+
+  > Line one
+  >
+  > Line two
+
+
+## Important & Not Immediate
+
+_No tasks._
+
+## Not Important & Immediate
+
+- [-] Renew cert <!-- id:BBB -->
+  - Delegate to: alice
+  - Effort: 1h
+
+## Not Important & Not Immediate
+
+- [x] Old data <!-- id:CCC -->
+  - Delete reason: nobody reads it
+`
+	if got := string(Marshal(tasks, now)); got != want {
+		t.Fatalf("unexpected document:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestMarshalOrder(t *testing.T) {
+	tasks := []model.Task{
+		{ID: "2", Title: "second", Important: true},
+		{ID: "1", Title: "first", Important: true},
+	}
+	got := string(Marshal(tasks, time.Now()))
+	if strings.Index(got, "second") > strings.Index(got, "first") {
+		t.Fatalf("expected the given order within a quadrant:\n%s", got)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	now := time.Date(2025, 1, 5, 9, 0, 0, 0, time.Local)
+	due := time.Date(2025, 1, 5, 13, 0, 0, 0, time.Local)
+	planned := time.Date(2025, 1, 8, 9, 0, 0, 0, time.Local)
+	tasks := []model.Task{
+		{ID: "AAA", Title: "Fix prod outage", Important: true, Urgent: true, DueAt: &due, Impact: "Revenue loss",
+			NextAction: "Restart DB", Tags: []string{"ops", "db"}, Project: "infra", Description: "Line one\n\nLine two", Status: model.StatusPending},
+		{ID: "BBB", Title: "Plan migration", Important: true, PlannedDate: &planned, EffortEstimate: "3sp", Status: model.StatusDeferred},
+		{ID: "CCC", Title: "Old data", Status: model.StatusDone, DeleteReason: "nobody reads it"},
+	}
+
+	items, err := Parse(Marshal(tasks, now))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) != len(tasks) {
+		t.Fatalf("expected %d items, got %+v", len(tasks), items)
+	}
+	for i, it := range items {
+		if it.ID != tasks[i].ID {
+			t.Fatalf("expected ID %q, got %q", tasks[i].ID, it.ID)
+		}
+		got, err := Apply(model.Task{ID: it.ID}, it, now)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if changes := model.Changes(tasks[i], got); len(changes) > 0 {
+			t.Fatalf("round trip of %q changed %v", tasks[i].Title, changes)
+		}
+		if got.Description != tasks[i].Description {
+			t.Fatalf("expected description %q, got %q", tasks[i].Description, got.Description)
+		}
+	}
+}
+
+func TestApplyKeepsUneditedDates(t *testing.T) {
+	now := time.Date(2025, 1, 5, 9, 0, 0, 0, time.Local)
+	due := time.Date(2025, 1, 5, 13, 0, 30, 0, time.Local)
+	task := model.Task{ID: "AAA", Title: "Renew cert", Important: true, Urgent: true, DueAt: &due, Status: model.StatusPending}
+
+	items, err := Parse(Marshal([]model.Task{task}, now))
+	if err != nil || len(items) != 1 {
+		t.Fatalf("unexpected parse result %+v, %v", items, err)
+	}
+	got, err := Apply(task, items[0], now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if changes := model.Changes(task, got); len(changes) > 0 {
+		t.Fatalf("expected no changes, got %v", changes)
+	}
+}
+
+func TestParsePlainList(t *testing.T) {
+	doc := `# Incident 42
+
+Follow-ups from the review:
+
+- [ ] Add disk alerts
+  - Due: 2025-01-10
+  - Owner: bob
+* [x] Rotate   keys
+- [ ]
+
+## Not Important & Immediate
+
+- [-] Reply to vendor <!-- id:XYZ -->
+- not a task
+`
+	items, err := Parse([]byte(doc))
+	if err == nil || !strings.Contains(err.Error(), "line 9") {
+		t.Fatalf("expected an error for the empty title on line 9, got %v", err)
+	}
+	doc = strings.Replace(doc, "- [ ]\n", "", 1)
+	items, err = Parse([]byte(doc))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) != 3 {
+		t.Fatalf("expected 3 items, got %+v", items)
+	}
+	first := items[0]
+	if first.Title != "Add disk alerts" || first.Quadrant != -1 || first.Status != model.StatusPending ||
+		len(first.Fields) != 1 || first.Fields["Due"] != "2025-01-10" {
+		t.Fatalf("unexpected first item %+v", first)
+	}
+	if items[1].Title != "Rotate keys" || items[1].Status != model.StatusDone {
+		t.Fatalf("unexpected second item %+v", items[1])
+	}
+	if items[2].ID != "XYZ" || items[2].Title != "Reply to vendor" || items[2].Quadrant != 2 || items[2].Status != model.StatusDeferred {
+		t.Fatalf("unexpected third item %+v", items[2])
+	}
+
+	base := model.Task{Important: true, Impact: "kept"}
+	got, err := Apply(base, first, time.Now())
+	if err != nil || got.Impact != "kept" || !got.Important || got.DueAt == nil {
+		t.Fatalf("expected fields merged into the base, got %+v (%v)", got, err)
+	}
+	first.Fields["Planned"] = "someday"
+	if _, err := Apply(base, first, time.Now()); err == nil || !strings.Contains(err.Error(), "line 5: Planned") {
+		t.Fatalf("expected a date error, got %v", err)
+	}
+}
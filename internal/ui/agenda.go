@@ -0,0 +1,133 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/mrbooshehri/actNow/internal/engine"
+	"github.com/mrbooshehri/actNow/internal/format"
+	"github.com/mrbooshehri/actNow/internal/model"
+)
+
+func (m Model) agendaItems() []engine.AgendaItem {
+	var out []engine.AgendaItem
+	for _, g := range engine.Agenda(m.tasks, time.Now(), 0) {
+		out = append(out, g.Items...)
+	}
+	return out
+}
+
+func (m Model) updateAgenda(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.statusMsg = ""
+	m.statusIsErr = false
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc", "q", "g":
+		m.mode = modeList
+		return m, nil
+	}
+	items := m.agendaItems()
+	if len(items) == 0 {
+		return m, nil
+	}
+	m.agendaIndex = clamp(m.agendaIndex, 0, len(items)-1)
+	idx := items[m.agendaIndex].Index
+
+	switch msg.String() {
+	case "up", "k":
+		m.agendaIndex = max(m.agendaIndex-1, 0)
+	case "down", "j":
+		m.agendaIndex = min(m.agendaIndex+1, len(items)-1)
+	case "tab":
+		pos := 0
+		for _, g := range engine.Agenda(m.tasks, time.Now(), 0) {
+			pos += len(g.Items)
+			if pos > m.agendaIndex {
+				break
+			}
+		}
+		if pos >= len(items) {
+			pos = 0
+		}
+		m.agendaIndex = pos
+	case "d":
+		m.updateTaskByID(m.tasks[idx].ID, "Marked done", func(t *model.Task) { t.Status = model.StatusDone })
+		m.agendaIndex = clamp(m.agendaIndex, 0, max(len(m.agendaItems())-1, 0))
+	case "e", "enter":
+		m.startForm(formEdit, m.tasks[idx])
+		return m, m.focusCmd()
+	}
+	return m, nil
+}
+
+func (m Model) agendaLines(width int) ([]string, int) {
+	groups := engine.Agenda(m.tasks, time.Now(), 0)
+	if len(groups) == 0 {
+		return []string{"Nothing due or planned. Set Due/SLA or Planned Date on tasks to see them here."}, 0
+	}
+	headingStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Bold(true)
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+	selectedStyle := lipgloss.NewStyle().Bold(true)
+	var lines []string
+	selectedLine, pos := 0, 0
+	for _, g := range groups {
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		style := headingStyle
+		if g.Name == "Overdue" {
+			style = escalateStyle
+		}
+		lines = append(lines, style.Render(fmt.Sprintf("%s (%d)", g.Name, len(g.Items))))
+		for _, it := range g.Items {
+			t := m.tasks[it.Index]
+			cursor := "  "
+			title := t.Title
+			if pos == m.agendaIndex {
+				selectedLine = len(lines)
+				cursor = "> "
+				title = selectedStyle.Render(title)
+			}
+			line := fmt.Sprintf("%s%s %-16s %-7s %s", cursor, format.QuadrantMarker(engine.QuadrantIndex(t)), format.AgendaTime(g.Name, it.At), it.Kind, title)
+			if len(t.Tags) > 0 {
+				line += dim.Render("  #" + strings.Join(t.Tags, " #"))
+			}
+			lines = append(lines, fitLine(line, width))
+			pos++
+		}
+	}
+	return lines, selectedLine
+}
+
+func (m Model) viewAgenda() string {
+	width, height := m.width, m.height
+	if width == 0 || height == 0 {
+		width, height = 80, 24
+	}
+	footer := "[j/k] move  [tab] next day  [e/enter] edit  [d] done  [esc] back"
+	extra := []string{}
+	if m.statusMsg != "" {
+		extra = append(extra, m.statusLine())
+	}
+	boxH := max(height-1-len(extra), 3)
+	lines, selectedLine := m.agendaLines(width - 2)
+	maxLines := boxH - 2
+	if len(lines) > maxLines {
+		start := clamp(selectedLine-maxLines/2, 0, len(lines)-maxLines)
+		lines = lines[start : start+maxLines]
+	}
+
+	border := lipgloss.ThickBorder()
+	borderStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+	textStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("255"))
+	box := renderPanelBox(border, borderStyle, textStyle, width, boxH, "AGENDA", strings.Join(lines, "\n"))
+	footerStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+	parts := append([]string{box}, extra...)
+	parts = append(parts, footerStyle.Render(footer))
+	return lipgloss.JoinVertical(lipgloss.Left, parts...)
+}
@@ -0,0 +1,304 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/mrbooshehri/actNow/internal/engine"
+	"github.com/mrbooshehri/actNow/internal/format"
+	"github.com/mrbooshehri/actNow/internal/model"
+	"github.com/mrbooshehri/actNow/internal/store"
+)
+
+var markStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Bold(true)
+
+// toggleMark marks or unmarks the task under the cursor and moves down.
+func (m *Model) toggleMark(visible []int) {
+	if len(visible) == 0 {
+		return
+	}
+	id := m.tasks[visible[m.selected]].ID
+	if m.marked == nil {
+		m.marked = map[string]bool{}
+	}
+	if m.marked[id] {
+		delete(m.marked, id)
+	} else {
+		m.marked[id] = true
+	}
+	if m.selected < len(visible)-1 {
+		m.selected++
+	}
+}
+
+func (m *Model) toggleVisual() {
+	if m.visualAnchor < 0 {
+		m.visualAnchor = m.selected
+		m.SetStatus("Visual: move to extend the range, [V] to mark it", false)
+		return
+	}
+	m.commitVisual()
+}
+
+// commitVisual adds the visual range to the marked tasks.
+func (m *Model) commitVisual() {
+	if m.visualAnchor < 0 {
+		return
+	}
+	if m.marked == nil {
+		m.marked = map[string]bool{}
+	}
+	for _, idx := range m.visualRange() {
+		m.marked[m.tasks[idx].ID] = true
+	}
+	m.visualAnchor = -1
+}
+
+func (m Model) visualRange() []int {
+	if m.visualAnchor < 0 {
+		return nil
+	}
+	visible := m.visibleIndices()
+	lo, hi := m.visualAnchor, m.selected
+	if lo > hi {
+		lo, hi = hi, lo
+	}
+	if len(visible) == 0 {
+		return nil
+	}
+	hi = clamp(hi, 0, len(visible)-1)
+	lo = clamp(lo, 0, hi)
+	return visible[lo : hi+1]
+}
+
+func (m Model) hasSelection() bool {
+	return len(m.marked) > 0 || m.visualAnchor >= 0
+}
+
+func (m *Model) clearSelection() {
+	m.marked = nil
+	m.visualAnchor = -1
+}
+
+// isMarked reports whether the task at idx is part of the selection.
+func (m Model) isMarked(idx int) bool {
+	if m.marked[m.tasks[idx].ID] {
+		return true
+	}
+	for _, i := range m.visualRange() {
+		if i == idx {
+			return true
+		}
+	}
+	return false
+}
+
+// targets are the marked tasks, or the one under the cursor.
+func (m Model) targets(visible []int) []int {
+	if !m.hasSelection() {
+		if len(visible) == 0 {
+			return nil
+		}
+		return []int{visible[m.selected]}
+	}
+	var out []int
+	for i := range m.tasks {
+		if m.isMarked(i) {
+			out = append(out, i)
+		}
+	}
+	return out
+}
+
+// bulkApply skips tasks for which fn returns an error.
+func (m *Model) bulkApply(targets []int, verb string, fn func(t *model.Task) error) {
+	if len(targets) == 0 {
+		return
+	}
+	changed, skipped := 0, 0
+	var reason string
+	for _, idx := range targets {
+		before := m.tasks[idx]
+		if err := fn(&m.tasks[idx]); err != nil {
+			skipped++
+			reason = err.Error()
+			continue
+		}
+		m.recordChanges(idx, before)
+		changed++
+	}
+	m.clearSelection()
+	m.saveTasks()
+	if m.statusIsErr {
+		return
+	}
+	msg := format.Plural(changed, "task", "tasks") + " " + verb
+	if skipped > 0 {
+		msg += fmt.Sprintf(" (%d skipped: %s)", skipped, reason)
+	}
+	m.SetStatus(msg, skipped > 0)
+}
+
+func (m *Model) bulkStatus(targets []int, status, verb string) {
+	m.bulkApply(targets, verb, func(t *model.Task) error {
+		t.Status = status
+		return nil
+	})
+}
+
+func (m *Model) bulkReclassify(targets []int, q int) {
+	now := time.Now()
+	m.bulkApply(targets, "moved to "+engine.Quadrant(engine.SetQuadrant(model.Task{}, q)), func(t *model.Task) error {
+		return moveTask(t, q, now)
+	})
+}
+
+// bulkToggle flips a quadrant bit of each target, as I and U do for one task.
+func (m *Model) bulkToggle(targets []int, bit int, verb string) {
+	now := time.Now()
+	m.bulkApply(targets, verb, func(t *model.Task) error {
+		return moveTask(t, engine.QuadrantIndex(*t)^bit, now)
+	})
+}
+
+// moveTask refuses to make a task due within 24h not urgent.
+func moveTask(t *model.Task, q int, now time.Time) error {
+	moved := engine.SetQuadrant(*t, q)
+	if !moved.Urgent && engine.ApplyUrgency(moved, now).Urgent {
+		return fmt.Errorf("due within 24h")
+	}
+	*t = moved
+	return nil
+}
+
+func (m *Model) moveToTrash(indices []int, eliminated bool) {
+	if len(indices) == 0 {
+		return
+	}
+	trash, ok := m.loadTrash()
+	if !ok {
+		return
+	}
+	now := time.Now()
+	drop := map[int]bool{}
+	for _, idx := range indices {
+		drop[idx] = true
+		trash = append(trash, model.TrashEntry{Task: m.tasks[idx], DeletedAt: now, Reason: m.tasks[idx].DeleteReason, Eliminated: eliminated})
+	}
+	if !m.saveTrash(trash) {
+		return
+	}
+	kept := make([]model.Task, 0, len(m.tasks)-len(drop))
+	for i, t := range m.tasks {
+		if !drop[i] {
+			kept = append(kept, t)
+		}
+	}
+	m.tasks = kept
+	m.clearSelection()
+	if n := len(m.visibleIndices()); m.selected >= n {
+		m.selected = max(n-1, 0)
+	}
+	m.saveTasks()
+	if !m.statusIsErr {
+		m.SetStatus(format.Plural(len(indices), "task", "tasks")+" moved to trash", false)
+	}
+}
+
+func (m *Model) loadTrash() ([]model.TrashEntry, bool) {
+	data, err := m.store.LoadTrash()
+	if err != nil {
+		m.setStatusErr("Failed to load trash")
+		return nil, false
+	}
+	var trash []model.TrashEntry
+	if err := store.DecodeTasks(data, &trash); err != nil {
+		m.setStatusErr("Trash file is corrupted")
+		return nil, false
+	}
+	return trash, true
+}
+
+// refreshTrash reloads the trash shown in the eliminated report.
+func (m *Model) refreshTrash() {
+	trash, ok := m.loadTrash()
+	if !ok {
+		m.trash, m.trashErr = nil, m.statusMsg
+		return
+	}
+	m.trash, m.trashErr = trash, ""
+}
+
+func (m *Model) saveTrash(trash []model.TrashEntry) bool {
+	data, err := store.EncodeTasks(trash)
+	if err != nil {
+		m.setStatusErr("Failed to encode trash")
+		return false
+	}
+	if err := m.store.SaveTrash(data); err != nil {
+		m.setStatusErr("Failed to save trash")
+		return false
+	}
+	m.trash, m.trashErr = trash, ""
+	return true
+}
+
+// purgeTrash always asks, whatever the config says.
+func (m *Model) purgeTrash() {
+	trash, ok := m.loadTrash()
+	if !ok {
+		return
+	}
+	if len(trash) == 0 {
+		m.SetStatus("Trash is empty", false)
+		return
+	}
+	msg := "Permanently delete " + format.Plural(len(trash), "task", "tasks") + " in the trash? This cannot be undone."
+	m.confirm("Purge trash", msg, true, func(m *Model, _ string) {
+		if m.saveTrash([]model.TrashEntry{}) {
+			m.SetStatus("Trash purged", false)
+		}
+	})
+}
+
+// tagTargets prompts for a tag edit to apply to the target tasks.
+func (m *Model) tagTargets(targets []int) tea.Cmd {
+	return m.prompt("Tags", "Edit tags of "+m.describe(targets)+": +add -remove, or a new list", "", func(m *Model, value string) {
+		m.bulkApply(targets, "re-tagged", func(t *model.Task) error {
+			t.Tags = model.EditTags(t.Tags, value)
+			return nil
+		})
+	})
+}
+
+// delegateTargets prompts for who the target tasks are delegated to.
+func (m *Model) delegateTargets(targets []int) tea.Cmd {
+	value := ""
+	if len(targets) == 1 {
+		value = m.tasks[targets[0]].DelegateTo
+	}
+	return m.prompt("Delegate", "Delegate "+m.describe(targets)+" to:", value, func(m *Model, value string) {
+		m.bulkApply(targets, "delegated", func(t *model.Task) error {
+			t.DelegateTo = value
+			return nil
+		})
+	})
+}
+
+// describe names the target tasks in a confirmation message.
+func (m Model) describe(targets []int) string {
+	if len(targets) == 1 {
+		return fmt.Sprintf("%q", m.tasks[targets[0]].Title)
+	}
+	return format.Plural(len(targets), "task", "tasks")
+}
+
+func (m Model) selectionLine() string {
+	n := len(m.targets(m.visibleIndices()))
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+	return markStyle.Render(format.Plural(n, "task", "tasks")+" selected") + dim.Render(
+		"  [d] done  [f] defer  [x] trash  [t] tag  [D] delegate  [1-4] move  [I/U] toggle  [esc] clear")
+}
@@ -0,0 +1,201 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/mrbooshehri/actNow/internal/dateparse"
+)
+
+// calendar.cursor holds both the selected day and the time of day.
+type calendar struct {
+	open      bool
+	field     formField
+	cursor    time.Time
+	typing    bool
+	timeInput textinput.Model
+}
+
+var (
+	dueMarkStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("203"))
+	plannedMarkStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("75"))
+)
+
+func (m *Model) openCalendar(field formField) {
+	p := m.pickerFor(field)
+	cursor := p.t
+	if !p.enabled {
+		y, mo, d := time.Now().Date()
+		cursor = time.Date(y, mo, d, dateparse.DayStart, 0, 0, 0, time.Local)
+	}
+	m.calendar = calendar{open: true, field: field, cursor: cursor}
+}
+
+func (m Model) updateCalendar(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	c := &m.calendar
+	if c.typing {
+		switch msg.String() {
+		case "esc":
+			c.typing = false
+		case "enter":
+			h, min, ok := dateparse.ParseClock(strings.ToLower(strings.TrimSpace(c.timeInput.Value())))
+			if !ok {
+				m.setStatusErr("Time: expected e.g. 9:30, 14:00 or 5pm")
+				return m, nil
+			}
+			y, mo, d := c.cursor.Date()
+			c.cursor = time.Date(y, mo, d, h, min, 0, 0, c.cursor.Location())
+			c.typing = false
+			m.statusMsg = ""
+			m.statusIsErr = false
+		default:
+			c.timeInput, _ = c.timeInput.Update(msg)
+		}
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc", "q":
+		c.open = false
+	case "h", "left":
+		c.cursor = c.cursor.AddDate(0, 0, -1)
+	case "l", "right":
+		c.cursor = c.cursor.AddDate(0, 0, 1)
+	case "k", "up":
+		c.cursor = c.cursor.AddDate(0, 0, -7)
+	case "j", "down":
+		c.cursor = c.cursor.AddDate(0, 0, 7)
+	case "[", "pgup", "H":
+		c.cursor = c.cursor.AddDate(0, -1, 0)
+	case "]", "pgdown", "L":
+		c.cursor = c.cursor.AddDate(0, 1, 0)
+	case ".", "T":
+		y, mo, d := time.Now().Date()
+		c.cursor = time.Date(y, mo, d, c.cursor.Hour(), c.cursor.Minute(), 0, 0, c.cursor.Location())
+	case "+", "=":
+		c.cursor = c.cursor.Add(15 * time.Minute)
+	case "-":
+		c.cursor = c.cursor.Add(-15 * time.Minute)
+	case "i", ":":
+		c.typing = true
+		c.timeInput = newInput("9:30, 14:00, 5pm", c.cursor.Format("15:04"))
+		c.timeInput.SetCursor(5)
+		return m, c.timeInput.Focus()
+	case "enter":
+		p := m.pickerFor(c.field)
+		p.enabled = true
+		p.t = c.cursor.Truncate(time.Minute)
+		c.open = false
+	}
+	return m, nil
+}
+
+// taskDateMarks maps days to "due" or "planned" for open tasks.
+func (m Model) taskDateMarks() map[string]string {
+	marks := map[string]string{}
+	for _, t := range m.tasks {
+		if t.IsDone() {
+			continue
+		}
+		if t.PlannedDate != nil {
+			if key := t.PlannedDate.Local().Format("2006-01-02"); marks[key] == "" {
+				marks[key] = "planned"
+			}
+		}
+		if t.DueAt != nil {
+			marks[t.DueAt.Local().Format("2006-01-02")] = "due"
+		}
+	}
+	return marks
+}
+
+// calendarLines starts weeks on Monday, with ISO week numbers.
+func (m Model) calendarLines() []string {
+	c := m.calendar
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+	head := lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Bold(true)
+	selected := lipgloss.NewStyle().Reverse(true).Bold(true)
+	today := lipgloss.NewStyle().Underline(true).Bold(true)
+	marks := m.taskDateMarks()
+
+	first := time.Date(c.cursor.Year(), c.cursor.Month(), 1, 0, 0, 0, 0, c.cursor.Location())
+	start := first.AddDate(0, 0, -((int(first.Weekday()) + 6) % 7))
+	todayKey := time.Now().Format("2006-01-02")
+
+	lines := []string{
+		head.Render(fmt.Sprintf("%-19s", c.cursor.Format("January 2006"))) + dim.Render(fmt.Sprintf("%13s", "[ ] month")),
+		dim.Render(" Wk   Mo  Tu  We  Th  Fr  Sa  Su"),
+	}
+	for week := start; !week.After(first.AddDate(0, 1, -1)); week = week.AddDate(0, 0, 7) {
+		_, wn := week.ISOWeek()
+		var b strings.Builder
+		b.WriteString(dim.Render(fmt.Sprintf(" %2d ", wn)))
+		for i := 0; i < 7; i++ {
+			day := week.AddDate(0, 0, i)
+			if day.Month() != first.Month() {
+				b.WriteString("    ")
+				continue
+			}
+			key := day.Format("2006-01-02")
+			num := fmt.Sprintf("%3d", day.Day())
+			switch {
+			case key == c.cursor.Format("2006-01-02"):
+				num = selected.Render(num)
+			case key == todayKey:
+				num = today.Render(num)
+			}
+			mark := " "
+			switch marks[key] {
+			case "due":
+				mark = dueMarkStyle.Render("•")
+			case "planned":
+				mark = plannedMarkStyle.Render("•")
+			}
+			b.WriteString(num + mark)
+		}
+		lines = append(lines, b.String())
+	}
+
+	timeLine := "Time: " + c.cursor.Format("15:04")
+	if c.typing {
+		c.timeInput.Width = 10
+		timeLine = "Time: " + c.timeInput.View()
+	}
+	lines = append(lines, "",
+		c.cursor.Format("Mon 2006-01-02")+"  "+timeLine,
+		dueMarkStyle.Render("•")+dim.Render(" due  ")+plannedMarkStyle.Render("•")+dim.Render(" planned"),
+		"",
+		dim.Render("[hjkl] day/week  [.] today  [i] time  [+/-] 15m"),
+		dim.Render("[enter] set  [esc] cancel"),
+	)
+	return lines
+}
+
+func (m Model) viewCalendar(base string) string {
+	lines := m.calendarLines()
+	boxW := 0
+	for _, line := range lines {
+		boxW = max(boxW, lipgloss.Width(line))
+	}
+	boxW += 4
+	title := "Due/SLA"
+	switch m.calendar.field {
+	case fieldPlanned:
+		title = "Planned Date"
+	case fieldFollowUp:
+		title = "Follow Up"
+	}
+	border := lipgloss.NormalBorder()
+	borderStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+	textStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("255"))
+	content := padModalContent(strings.Join(lines, "\n"), 1, boxW-2)
+	box := renderPanelBox(border, borderStyle, textStyle, boxW, len(lines)+2, title, content)
+	return overlayCenter(base, box, m.width, m.height)
+}
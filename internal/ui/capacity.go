@@ -0,0 +1,152 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/mrbooshehri/actNow/internal/engine"
+	"github.com/mrbooshehri/actNow/internal/format"
+)
+
+// capacityDays is how far ahead the planning view looks.
+const capacityDays = 14
+
+var overbookedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Bold(true)
+
+// formatEffort adds the parsed duration, e.g. "3sp (4h)".
+func (m Model) formatEffort(effort string) string {
+	effort = strings.TrimSpace(effort)
+	if effort == "" {
+		return ""
+	}
+	d, err := engine.ParseEffort(effort, m.cfg.Points())
+	if err != nil {
+		return effort + " (not a valid estimate)"
+	}
+	if formatted := format.Hours(d); formatted != effort {
+		return effort + " (" + formatted + ")"
+	}
+	return effort
+}
+
+// capacityBar draws used against capacity, in red past capacity.
+func capacityBar(used, capacity time.Duration, width int) string {
+	if capacity <= 0 || width <= 0 {
+		return ""
+	}
+	filled := int(float64(width) * float64(used) / float64(capacity))
+	if filled > width {
+		return overbookedStyle.Render(strings.Repeat("█", width))
+	}
+	color := lipgloss.Color("34")
+	if used == capacity {
+		color = lipgloss.Color("214")
+	}
+	return lipgloss.NewStyle().Foreground(color).Render(strings.Repeat("█", filled)) +
+		lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render(strings.Repeat("░", width-filled))
+}
+
+func (m Model) updateCapacity(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc", "q", "c":
+		m.mode = modeList
+	case "up", "k":
+		m.capacityOffset = max(m.capacityOffset-1, 0)
+	case "down", "j":
+		m.capacityOffset++
+	case "pgup":
+		m.capacityOffset = max(m.capacityOffset-10, 0)
+	case "pgdown":
+		m.capacityOffset += 10
+	}
+	m.capacityOffset = clamp(m.capacityOffset, 0, m.maxCapacityOffset())
+	return m, nil
+}
+
+func (m Model) capacitySize() (int, int) {
+	width, height := m.width, m.height
+	if width == 0 || height == 0 {
+		width, height = 80, 24
+	}
+	return width, max(height-1, 3)
+}
+
+func (m Model) maxCapacityOffset() int {
+	width, boxH := m.capacitySize()
+	return max(len(m.capacityLines(width-2))-(boxH-2), 0)
+}
+
+func (m Model) capacityLines(width int) []string {
+	now := time.Now()
+	capacity := m.cfg.Capacity()
+	loads := engine.PlanCapacity(m.tasks, now, capacityDays, m.cfg.Points())
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+	dayStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Bold(true)
+
+	unplanned := 0
+	for _, t := range m.tasks {
+		if engine.QuadrantIndex(t) == 1 && !t.IsDone() && t.PlannedDate == nil {
+			unplanned++
+		}
+	}
+	overbooked := 0
+	for _, l := range loads {
+		if l.Overbooked(capacity) {
+			overbooked++
+		}
+	}
+	summary := fmt.Sprintf("Daily capacity %s · %s without a planned date", format.Hours(capacity), format.Plural(unplanned, "I+NI task", "I+NI tasks"))
+	lines := []string{dim.Render(summary)}
+	if overbooked > 0 {
+		lines = append(lines, overbookedStyle.Render(format.Plural(overbooked, "day", "days")+" overbooked"))
+	}
+
+	barW := clamp(width-48, 8, 30)
+	for _, l := range loads {
+		lines = append(lines, "")
+		head := fmt.Sprintf("%s  %s  %s/%s  %s", dayStyle.Render(l.Day.Format("Mon 2006-01-02")),
+			capacityBar(l.Effort, capacity, barW), format.Hours(l.Effort), format.Hours(capacity), format.Plural(len(l.Indices), "task", "tasks"))
+		if l.Overbooked(capacity) {
+			head += "  " + overbookedStyle.Render("OVERBOOKED +"+format.Hours(l.Effort-capacity))
+		}
+		lines = append(lines, fitLine(head, width))
+		for _, idx := range l.Indices {
+			t := m.tasks[idx]
+			effort := m.formatEffort(t.EffortEstimate)
+			if effort == "" {
+				effort = "no estimate"
+			}
+			lines = append(lines, fitLine("    "+t.Title+dim.Render("  "+effort), width))
+		}
+		if l.Unestimated > 0 {
+			lines = append(lines, fitLine(dim.Render("    "+format.Plural(l.Unestimated, "task", "tasks")+" not counted: no valid effort estimate"), width))
+		}
+	}
+	return lines
+}
+
+func (m Model) viewCapacity() string {
+	width, boxH := m.capacitySize()
+	footer := "[j/k, pgup/pgdown] scroll  [esc] back   capacity and story points: ~/.actnow/config.json"
+	lines := m.capacityLines(width - 2)
+	maxLines := boxH - 2
+	if len(lines) > maxLines {
+		offset := clamp(m.capacityOffset, 0, len(lines)-maxLines)
+		lines = lines[offset : offset+maxLines]
+	}
+
+	borderColor, _ := quadrantColors(1)
+	border := lipgloss.ThickBorder()
+	borderStyle := lipgloss.NewStyle().Foreground(borderColor)
+	textStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("255"))
+	title := fmt.Sprintf("CAPACITY — IMPORTANT & NOT IMMEDIATE, NEXT %d DAYS", capacityDays)
+	box := renderPanelBox(border, borderStyle, textStyle, width, boxH, title, strings.Join(lines, "\n"))
+	footerStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+	return lipgloss.JoinVertical(lipgloss.Left, box, footerStyle.Render(footer))
+}
@@ -0,0 +1,259 @@
+package ui
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/mrbooshehri/actNow/internal/engine"
+	"github.com/mrbooshehri/actNow/internal/format"
+	"github.com/mrbooshehri/actNow/internal/quickadd"
+)
+
+// splitMinWidth is the width from which details show as a side pane.
+const splitMinWidth = 120
+
+func (m Model) selectedIndex() (int, bool) {
+	visible := m.visibleIndices()
+	if len(visible) == 0 || m.selected < 0 || m.selected >= len(visible) {
+		return 0, false
+	}
+	return visible[m.selected], true
+}
+
+func (m Model) updateDetail(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	idx, ok := m.selectedIndex()
+	if !ok {
+		m.mode = modeList
+		return m, nil
+	}
+	visible := m.visibleIndices()
+
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc", "q", "enter":
+		m.mode = modeList
+		return m, nil
+	case "e":
+		m.startForm(formEdit, m.tasks[idx])
+		return m, m.focusCmd()
+	case "E":
+		m.mode = modeList
+		return m, m.openInEditor(idx)
+	case "up", "k":
+		if m.selected > 0 {
+			m.selected--
+			m.detailOffset = 0
+		}
+	case "down", "j":
+		if m.selected < len(visible)-1 {
+			m.selected++
+			m.detailOffset = 0
+		}
+	case "tab", "shift+tab":
+		if msg.String() == "tab" {
+			m.nextSection(1)
+		} else {
+			m.nextSection(-1)
+		}
+		m.detailOffset = 0
+		if len(m.visibleIndices()) == 0 {
+			m.mode = modeList
+		}
+		return m, nil
+	case "pgup":
+		m.detailOffset -= 5
+	case "pgdown":
+		m.detailOffset += 5
+	}
+	m.detailOffset = clamp(m.detailOffset, 0, m.maxDetailOffset())
+	return m, nil
+}
+
+func (m Model) detailLines(width int) []string {
+	idx, ok := m.selectedIndex()
+	if !ok {
+		return []string{"(no task selected)"}
+	}
+	task := m.tasks[idx]
+	now := time.Now()
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("220"))
+	borderColor, _ := quadrantColors(engine.QuadrantIndex(task))
+	titleStyle := lipgloss.NewStyle().Foreground(borderColor).Bold(true)
+
+	lines := []string{}
+	for _, line := range wrapLineHard(task.Title, width) {
+		lines = append(lines, titleStyle.Render(line))
+	}
+	lines = append(lines, "")
+
+	field := func(label, value string) {
+		if value == "" {
+			value = "-"
+		}
+		prefix := labelStyle.Render(label + ": ")
+		prefixWidth := len([]rune(label)) + 2
+		available := width - prefixWidth
+		if available < 4 {
+			lines = append(lines, prefix+value)
+			return
+		}
+		for i, seg := range wrapLineHard(value, available) {
+			if i == 0 {
+				lines = append(lines, prefix+seg)
+				continue
+			}
+			lines = append(lines, strings.Repeat(" ", prefixWidth)+seg)
+		}
+	}
+
+	field("ID", task.ID)
+	field("Quadrant", engine.Quadrant(task))
+	field("Status", task.Status)
+	field("Important", yesNo(task.Important))
+	field("Urgent", yesNo(task.Urgent))
+	field("Due/SLA", formatDue(task.DueAt, now))
+	field("Planned Date", formatDate(task.PlannedDate))
+	field("Impact", task.Impact)
+	field("Next Action", task.NextAction)
+	field("Effort", m.formatEffort(task.EffortEstimate))
+	if spent := m.timeSpentLine(idx); spent != "" {
+		field("Time Spent", spent)
+	}
+	field("Delegate To", task.DelegateTo)
+	field("Follow Up", formatDue(task.FollowUpAt, now))
+	field("Delete Reason", task.DeleteReason)
+	field("Tags", formatTags(task.Tags))
+	field("Project", task.Project)
+	field("Quick-add", quickadd.Format(task))
+	if task.Pinned {
+		field("Pinned", "yes (the one thing)")
+	}
+	field("Created", task.CreatedAt.Format("2006-01-02 15:04")+" ("+format.Duration(now.Sub(task.CreatedAt))+" ago)")
+
+	lines = append(lines, "")
+	lines = append(lines, labelStyle.Render("Description"))
+	if strings.TrimSpace(task.Description) == "" {
+		lines = append(lines, "(no description)")
+	} else {
+		lines = append(lines, wrapParagraphs(task.Description, width)...)
+	}
+
+	lines = append(lines, "")
+	lines = append(lines, labelStyle.Render("History"))
+	if len(task.History) == 0 {
+		lines = append(lines, "(no history)")
+	}
+	for i := len(task.History) - 1; i >= 0; i-- {
+		event := task.History[i]
+		prefix := event.At.Format("2006-01-02 15:04") + "  "
+		for j, seg := range wrapLineHard(event.Note, max(4, width-len(prefix))) {
+			if j == 0 {
+				lines = append(lines, prefix+seg)
+				continue
+			}
+			lines = append(lines, strings.Repeat(" ", len(prefix))+seg)
+		}
+	}
+	return lines
+}
+
+func formatTags(tags []string) string {
+	out := make([]string, len(tags))
+	for i, tag := range tags {
+		out[i] = "#" + tag
+	}
+	return strings.Join(out, " ")
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
+
+func formatDate(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format("2006-01-02 15:04")
+}
+
+func formatDue(due *time.Time, now time.Time) string {
+	if due == nil {
+		return ""
+	}
+	d := due.Sub(now)
+	if d < 0 {
+		return formatDate(due) + " (overdue by " + format.Duration(-d) + ")"
+	}
+	return formatDate(due) + " (in " + format.Duration(d) + ")"
+}
+
+// detailSize also reports whether details show as a split pane.
+func (m Model) detailSize() (int, int, bool) {
+	width := m.width
+	height := m.height
+	if width == 0 || height == 0 {
+		width = 80
+		height = 24
+	}
+	if width >= splitMinWidth {
+		return width - width*3/5 - 1, height, true
+	}
+	return width, height, false
+}
+
+func (m Model) viewDetail() string {
+	width, height, split := m.detailSize()
+
+	footer := "[↑/↓, j/k] prev/next task  [tab] next section  [pgup/pgdown] scroll  [e] edit  [E] $EDITOR  [enter/esc/q] back"
+	usableHeight := height - 2
+	if usableHeight < 1 {
+		usableHeight = 1
+	}
+
+	lines := m.detailLines(width)
+	offset := clamp(m.detailOffset, 0, max(0, len(lines)-usableHeight))
+	end := offset + usableHeight
+	if end > len(lines) {
+		end = len(lines)
+	}
+	view := lines[offset:end]
+
+	headerStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Bold(true)
+	footerStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+
+	pane := make([]string, 0, usableHeight+1)
+	pane = append(pane, headerStyle.Render("TASK"))
+	pane = append(pane, view...)
+	for len(pane) < height-1 {
+		pane = append(pane, "")
+	}
+	body := strings.Join(pane, "\n")
+	if split {
+		gridW := m.width - width - 1
+		grid := m.viewBody(gridW, height-1)
+		body = lipgloss.JoinHorizontal(lipgloss.Top, grid, " ", strings.Join(padToSize(body, width, height-1), "\n"))
+		width = m.width
+	}
+
+	return padToScreen(body+"\n"+footerStyle.Render(footer), width, height)
+}
+
+func (m Model) maxDetailOffset() int {
+	width, height, _ := m.detailSize()
+	usableHeight := height - 2
+	if usableHeight < 1 {
+		usableHeight = 1
+	}
+	lines := m.detailLines(width)
+	if len(lines) <= usableHeight {
+		return 0
+	}
+	return len(lines) - usableHeight
+}
@@ -0,0 +1,60 @@
+package ui
+
+import (
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/mrbooshehri/actNow/internal/taskdoc"
+)
+
+type editorFinishedMsg struct {
+	taskID string
+	path   string
+	err    error
+}
+
+func (m *Model) openInEditor(idx int) tea.Cmd {
+	task := m.tasks[idx]
+	path, err := taskdoc.WriteTemp(task)
+	if err != nil {
+		m.setStatusErr("Failed to create temp file: " + err.Error())
+		return nil
+	}
+	return tea.ExecProcess(taskdoc.Command(path), func(err error) tea.Msg {
+		return editorFinishedMsg{taskID: task.ID, path: path, err: err}
+	})
+}
+
+func (m Model) handleEditorFinished(msg editorFinishedMsg) (tea.Model, tea.Cmd) {
+	defer os.Remove(msg.path)
+	if msg.err != nil {
+		m.setStatusErr("Editor failed: " + msg.err.Error())
+		return m, nil
+	}
+	data, err := os.ReadFile(msg.path)
+	if err != nil {
+		m.setStatusErr("Failed to read edited task: " + err.Error())
+		return m, nil
+	}
+	for i := range m.tasks {
+		if m.tasks[i].ID != msg.taskID {
+			continue
+		}
+		edited, err := taskdoc.Apply(m.tasks[i], data)
+		if err != nil {
+			m.setStatusErr("Edit discarded: " + err.Error())
+			return m, nil
+		}
+		before := m.tasks[i]
+		m.tasks[i] = edited
+		m.recordChanges(i, before)
+		m.saveTasks()
+		if !m.statusIsErr {
+			m.SetStatus("Task updated", false)
+		}
+		return m, nil
+	}
+	m.setStatusErr("Task no longer exists")
+	return m, nil
+}
@@ -0,0 +1,204 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/mrbooshehri/actNow/internal/engine"
+	"github.com/mrbooshehri/actNow/internal/format"
+	"github.com/mrbooshehri/actNow/internal/model"
+)
+
+// eliminateCandidates returns the open NI+NI tasks in their quadrant order.
+func (m Model) eliminateCandidates() []int {
+	var out []int
+	for i, t := range m.tasks {
+		if engine.QuadrantIndex(t) == 3 && !t.IsDone() {
+			out = append(out, i)
+		}
+	}
+	engine.SortIndices(m.tasks, out, m.cfg.SortFor(3), m.cfg.Points())
+	return out
+}
+
+func (m Model) startEliminate() (tea.Model, tea.Cmd) {
+	m.mode = modeEliminate
+	m.eliminateIndex = 0
+	m.eliminateReport = false
+	return m, nil
+}
+
+func (m Model) updateEliminate(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	candidates := m.eliminateCandidates()
+	m.statusMsg = ""
+	m.statusIsErr = false
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc", "q", "X":
+		m.mode = modeList
+		return m, nil
+	case "tab":
+		m.eliminateReport = !m.eliminateReport
+		if m.eliminateReport {
+			m.refreshTrash()
+		}
+		return m, nil
+	}
+	if m.eliminateReport || len(candidates) == 0 {
+		return m, nil
+	}
+	m.eliminateIndex = clamp(m.eliminateIndex, 0, len(candidates)-1)
+	idx := candidates[m.eliminateIndex]
+
+	switch msg.String() {
+	case "up", "k":
+		m.eliminateIndex = max(m.eliminateIndex-1, 0)
+	case "down", "j":
+		m.eliminateIndex = min(m.eliminateIndex+1, len(candidates)-1)
+	case "r":
+		return m, m.prompt("Delete reason", "Why drop "+m.describe([]int{idx})+"?", m.tasks[idx].DeleteReason, func(m *Model, value string) {
+			m.setDeleteReason(idx, value)
+		})
+	case "x", "enter":
+		if strings.TrimSpace(m.tasks[idx].DeleteReason) == "" {
+			return m, m.prompt("Eliminate", "Why drop "+m.describe([]int{idx})+"? The reason is kept with it.", "", func(m *Model, value string) {
+				if value == "" {
+					m.setStatusErr("A reason is required to eliminate a task")
+					return
+				}
+				m.setDeleteReason(idx, value)
+				m.eliminate(idx)
+			})
+		}
+		m.confirm("Eliminate", "Drop "+m.describe([]int{idx})+" ("+m.tasks[idx].DeleteReason+")?", false, func(m *Model, _ string) {
+			m.eliminate(idx)
+		})
+	}
+	return m, nil
+}
+
+func (m *Model) setDeleteReason(idx int, reason string) {
+	before := m.tasks[idx]
+	m.tasks[idx].DeleteReason = reason
+	m.recordChanges(idx, before)
+	m.saveTasks()
+}
+
+func (m *Model) eliminate(idx int) {
+	title := m.tasks[idx].Title
+	m.moveToTrash([]int{idx}, true)
+	m.eliminateIndex = clamp(m.eliminateIndex, 0, max(len(m.eliminateCandidates())-1, 0))
+	if !m.statusIsErr {
+		m.SetStatus(fmt.Sprintf("Eliminated %q", title), false)
+	}
+}
+
+// eliminateLines also returns the line of the selected task.
+func (m Model) eliminateLines(width int) ([]string, int) {
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+	if m.eliminateReport {
+		return m.eliminatedReportLines(width), 0
+	}
+	candidates := m.eliminateCandidates()
+	if len(candidates) == 0 {
+		return []string{"Nothing left to eliminate."}, 0
+	}
+	var lines []string
+	selectedLine := 0
+	now := time.Now()
+	for i, idx := range candidates {
+		t := m.tasks[idx]
+		if i == m.eliminateIndex {
+			selectedLine = len(lines)
+		}
+		lines = append(lines, m.taskLines(idx, i == m.eliminateIndex, nil, width)...)
+		reason := t.DeleteReason
+		if reason == "" {
+			reason = "(no reason yet)"
+		}
+		untouched := format.Duration(now.Sub(engine.LastTouched(t)))
+		lines = append(lines, fitLine(dim.Render("      reason: "+reason+"  ·  untouched "+untouched), width))
+	}
+	return lines, selectedLine
+}
+
+func (m Model) eliminatedReportLines(width int) []string {
+	if m.trashErr != "" {
+		return []string{m.trashErr}
+	}
+	var entries []model.TrashEntry
+	for _, e := range m.trash {
+		if e.Eliminated {
+			entries = append(entries, e)
+		}
+	}
+	if len(entries) == 0 {
+		return []string{"Nothing eliminated yet."}
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].DeletedAt.After(entries[j].DeletedAt) })
+
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Bold(true)
+	counts := map[string]int{}
+	var reasons []string
+	lines := []string{labelStyle.Render(format.Plural(len(entries), "task", "tasks") + " eliminated")}
+	for _, e := range entries {
+		reason := e.Reason
+		if reason == "" {
+			reason = "(no reason)"
+		}
+		if counts[reason] == 0 {
+			reasons = append(reasons, reason)
+		}
+		counts[reason]++
+		lines = append(lines, fitLine(e.DeletedAt.Format("2006-01-02")+"  "+e.Task.Title, width))
+		lines = append(lines, fitLine(dim.Render("            "+reason), width))
+	}
+	sort.SliceStable(reasons, func(i, j int) bool { return counts[reasons[i]] > counts[reasons[j]] })
+	lines = append(lines, "", labelStyle.Render("By reason"))
+	for _, r := range reasons {
+		lines = append(lines, fitLine(fmt.Sprintf("%3d  %s", counts[r], r), width))
+	}
+	return lines
+}
+
+func (m Model) viewEliminate() string {
+	width, height := m.width, m.height
+	if width == 0 || height == 0 {
+		width, height = 80, 24
+	}
+	footer := "[j/k] move  [x/enter] eliminate  [r] set reason  [tab] report  [esc] back"
+	title := "ELIMINATE — NOT IMPORTANT & NOT IMMEDIATE"
+	if m.eliminateReport {
+		footer = "[tab] review  [esc] back"
+		title = "ELIMINATED WORK"
+	}
+	extra := []string{}
+	if m.statusMsg != "" {
+		extra = append(extra, m.statusLine())
+	}
+	boxH := max(height-1-len(extra), 3)
+
+	lines, selectedLine := m.eliminateLines(width - 2)
+	maxLines := boxH - 2
+	if len(lines) > maxLines {
+		start := clamp(selectedLine-maxLines/2, 0, len(lines)-maxLines)
+		lines = lines[start : start+maxLines]
+	}
+
+	borderColor, _ := quadrantColors(3)
+	border := lipgloss.ThickBorder()
+	borderStyle := lipgloss.NewStyle().Foreground(borderColor)
+	textStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("255"))
+	box := renderPanelBox(border, borderStyle, textStyle, width, boxH, title, strings.Join(lines, "\n"))
+	footerStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+	parts := append([]string{box}, extra...)
+	parts = append(parts, footerStyle.Render(footer))
+	return lipgloss.JoinVertical(lipgloss.Left, parts...)
+}
@@ -0,0 +1,233 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/mrbooshehri/actNow/internal/engine"
+	"github.com/mrbooshehri/actNow/internal/model"
+)
+
+func (m *Model) startFocus() tea.Cmd {
+	m.mode = modeFocus
+	m.focusSkipped = nil
+	return m.tick()
+}
+
+// focusQueue moves skipped tasks to the back.
+func (m Model) focusQueue() []int {
+	var indices []int
+	for i, t := range m.tasks {
+		if engine.QuadrantIndex(t) == 0 && t.Status == model.StatusPending {
+			indices = append(indices, i)
+		}
+	}
+	engine.SortIndices(m.tasks, indices, m.quadrantSort(0), m.cfg.Points())
+	var queue, skipped []int
+	for _, i := range indices {
+		if !slices.Contains(m.focusSkipped, m.tasks[i].ID) {
+			queue = append(queue, i)
+		}
+	}
+	for _, id := range m.focusSkipped {
+		for _, i := range indices {
+			if m.tasks[i].ID == id {
+				skipped = append(skipped, i)
+			}
+		}
+	}
+	return append(queue, skipped...)
+}
+
+func (m Model) updateFocus(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.statusMsg = ""
+	m.statusIsErr = false
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc", "q", "F":
+		m.mode = modeList
+		return m, nil
+	}
+	queue := m.focusQueue()
+	if len(queue) == 0 {
+		return m, nil
+	}
+	idx := queue[0]
+	task := m.tasks[idx]
+	id := task.ID
+
+	switch msg.String() {
+	case "d":
+		m.updateTaskByID(id, "Done: "+task.Title, func(t *model.Task) {
+			t.StopTimer(time.Now())
+			t.Status = model.StatusDone
+		})
+	case "t":
+		return m, m.toggleTimer(idx)
+	case "O":
+		return m, m.togglePomodoro(idx)
+	case "s":
+		if len(queue) == 1 {
+			m.SetStatus("Nothing else is Important & Immediate", false)
+			return m, nil
+		}
+		m.focusSkipped = slices.DeleteFunc(m.focusSkipped, func(s string) bool { return s == id })
+		m.focusSkipped = append(m.focusSkipped, id)
+	case "f":
+		return m, m.prompt("Defer", "Defer "+m.describe([]int{idx})+" until (e.g. tomorrow, +3d; empty for no date):", "", func(m *Model, value string) {
+			at, err := parseOptionalDate(value, time.Now())
+			if err != nil {
+				m.setStatusErr("Defer: " + err.Error())
+				return
+			}
+			m.updateTaskByID(id, "Deferred: "+task.Title, func(t *model.Task) {
+				t.Status = model.StatusDeferred
+				if at != nil {
+					t.PlannedDate = at
+				}
+			})
+		})
+	case "e", "enter":
+		m.startForm(formEdit, task)
+		return m, m.focusCmd()
+	}
+	return m, nil
+}
+
+// formatClock renders d as h:mm:ss, or m:ss under an hour.
+func formatClock(d time.Duration) string {
+	d = d.Truncate(time.Second)
+	h := int(d.Hours())
+	mins := int(d.Minutes()) % 60
+	secs := int(d.Seconds()) % 60
+	if h == 0 {
+		return fmt.Sprintf("%02d:%02d", mins, secs)
+	}
+	return fmt.Sprintf("%d:%02d:%02d", h, mins, secs)
+}
+
+// bigGlyphs draws digits, ':' and '-' five rows tall.
+var bigGlyphs = map[rune][5]string{
+	'0': {"███", "█ █", "█ █", "█ █", "███"},
+	'1': {"██ ", " █ ", " █ ", " █ ", "███"},
+	'2': {"███", "  █", "███", "█  ", "███"},
+	'3': {"███", "  █", "███", "  █", "███"},
+	'4': {"█ █", "█ █", "███", "  █", "  █"},
+	'5': {"███", "█  ", "███", "  █", "███"},
+	'6': {"███", "█  ", "███", "█ █", "███"},
+	'7': {"███", "  █", "  █", "  █", "  █"},
+	'8': {"███", "█ █", "███", "█ █", "███"},
+	'9': {"███", "█ █", "███", "  █", "███"},
+	':': {" ", "█", " ", "█", " "},
+	'-': {"   ", "   ", "███", "   ", "   "},
+}
+
+// bigText falls back to s when it does not fit in width.
+func bigText(s string, width int) []string {
+	var rows [5]string
+	for i, r := range s {
+		g, ok := bigGlyphs[r]
+		if !ok {
+			return []string{s}
+		}
+		for row := range rows {
+			if i > 0 {
+				rows[row] += " "
+			}
+			rows[row] += g[row]
+		}
+	}
+	if lipgloss.Width(rows[0]) > width {
+		return []string{s}
+	}
+	return rows[:]
+}
+
+func (m Model) focusLines(width int) []string {
+	queue := m.focusQueue()
+	if len(queue) == 0 {
+		return []string{
+			lipgloss.NewStyle().Bold(true).Render("Nothing Important & Immediate."),
+			"",
+			"Act now on what matters next: press esc and pick from I+NI.",
+		}
+	}
+	task := m.tasks[queue[0]]
+	now := time.Now()
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("196"))
+	wrap := lipgloss.NewStyle().Width(min(width, 72)).Align(lipgloss.Center)
+
+	title := strings.ToUpper(task.Title)
+	if task.Pinned {
+		title = "★ " + title
+	}
+	lines := []string{
+		labelStyle.Render(fmt.Sprintf("IMPORTANT & IMMEDIATE  ·  1 of %d", len(queue))),
+		"",
+		wrap.Inherit(titleStyle).Render(title),
+		"",
+	}
+	field := func(label, value string) {
+		if value == "" {
+			value = labelStyle.Render("not set")
+		}
+		lines = append(lines, labelStyle.Render(label), wrap.Render(value), "")
+	}
+	field("Impact", task.Impact)
+	field("Next action", task.NextAction)
+
+	if task.DueAt == nil {
+		lines = append(lines, labelStyle.Render("No SLA set"))
+	} else {
+		d := task.DueAt.Sub(now)
+		clock := formatClock(d.Abs())
+		style := lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Bold(true)
+		label := "SLA due " + formatDate(task.DueAt)
+		if d < 0 {
+			clock = "-" + clock
+			style = escalateStyle
+			label = "SLA missed " + formatDate(task.DueAt)
+		}
+		lines = append(lines, labelStyle.Render(label))
+		for _, row := range bigText(clock, width) {
+			lines = append(lines, style.Render(row))
+		}
+	}
+	if spent := m.timeSpentLine(queue[0]); spent != "" {
+		lines = append(lines, "", labelStyle.Render("Time spent ")+spent)
+	}
+	if line := m.timerLine(); line != "" {
+		lines = append(lines, "", line)
+	}
+	return lines
+}
+
+func (m Model) viewFocus() string {
+	width, height := m.width, m.height
+	if width == 0 || height == 0 {
+		width, height = 80, 24
+	}
+	footer := "[d] done  [s] skip  [f] defer  [t] timer  [O] pomodoro  [e] edit  [esc] back"
+	extra := []string{}
+	if m.statusMsg != "" {
+		extra = append(extra, m.statusLine())
+	}
+	bodyH := max(height-1-len(extra), 3)
+	lines := m.focusLines(width - 4)
+	if len(lines) > bodyH {
+		lines = lines[:bodyH]
+	}
+	body := lipgloss.Place(width, bodyH, lipgloss.Center, lipgloss.Center,
+		lipgloss.JoinVertical(lipgloss.Center, lines...))
+	footerStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+	parts := append([]string{body}, extra...)
+	parts = append(parts, footerStyle.Render(footer))
+	return lipgloss.JoinVertical(lipgloss.Left, parts...)
+}
@@ -0,0 +1,99 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// dialog captures all keys until answered; onConfirm gets the prompt value.
+type dialog struct {
+	title     string
+	message   string
+	prompt    bool
+	input     textinput.Model
+	onConfirm func(m *Model, value string)
+}
+
+// confirm skips the question when the config says so, unless always.
+func (m *Model) confirm(title, message string, always bool, onConfirm func(m *Model, value string)) {
+	if !always && m.cfg != nil && m.cfg.SkipConfirm {
+		onConfirm(m, "")
+		return
+	}
+	m.dialog = &dialog{title: title, message: message, onConfirm: onConfirm}
+}
+
+// prompt asks for a line of text, prefilled with value.
+func (m *Model) prompt(title, message, value string, onConfirm func(m *Model, value string)) tea.Cmd {
+	input := textinput.New()
+	input.Prompt = "> "
+	input.CharLimit = 200
+	input.SetValue(value)
+	input.SetCursor(len([]rune(value)))
+	input.Width = m.dialogWidth() - 8
+	m.dialog = &dialog{title: title, message: message, prompt: true, input: input, onConfirm: onConfirm}
+	return m.dialog.input.Focus()
+}
+
+func (m Model) dialogWidth() int {
+	boxW := 56
+	if m.width > 0 && boxW > m.width-4 {
+		boxW = m.width - 4
+	}
+	return boxW
+}
+
+func (m *Model) sizeDialog() {
+	if m.dialog == nil || !m.dialog.prompt {
+		return
+	}
+	d := *m.dialog
+	d.input.Width = m.dialogWidth() - 8
+	m.dialog = &d
+}
+
+func (m Model) updateDialog(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	d := *m.dialog
+	key := msg.String()
+	switch {
+	case key == "ctrl+c":
+		return m, tea.Quit
+	case key == "esc", !d.prompt && (key == "n" || key == "N" || key == "q"):
+		m.dialog = nil
+		return m, nil
+	case key == "enter", !d.prompt && (key == "y" || key == "Y"):
+		m.dialog = nil
+		d.onConfirm(&m, strings.TrimSpace(d.input.Value()))
+		return m, nil
+	}
+	if !d.prompt {
+		return m, nil
+	}
+	var cmd tea.Cmd
+	d.input, cmd = d.input.Update(msg)
+	m.dialog = &d
+	return m, cmd
+}
+
+func (m Model) viewDialog(base string) string {
+	d := m.dialog
+	boxW := m.dialogWidth()
+	lines := flattenWrapped([]string{wrapLine(d.message, boxW-4)})
+	lines = append(lines, "")
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+	if d.prompt {
+		lines = append(lines, d.input.View(), "", dim.Render("[enter] apply  [esc] cancel"))
+	} else {
+		lines = append(lines, dim.Render("[y/enter] yes  [n/esc] no"))
+	}
+
+	border := lipgloss.NormalBorder()
+	borderStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+	textStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("255"))
+	content := padModalContent(strings.Join(lines, "\n"), 1, boxW-2)
+	box := renderPanelBox(border, borderStyle, textStyle, boxW, len(lines)+2, d.title, content)
+	return overlayCenter(base, box, m.width, m.height)
+}
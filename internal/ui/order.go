@@ -0,0 +1,95 @@
+package ui
+
+import (
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/mrbooshehri/actNow/internal/engine"
+)
+
+var pinnedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("220")).Bold(true)
+
+// moveTask swaps the selected task with its neighbour (delta -1 or 1).
+func (m *Model) moveTask(delta int) {
+	if m.grouped() || m.quadrantSort(m.quadrant) != engine.SortManual {
+		m.SetStatus("Switch to manual sort [s] to reorder tasks", true)
+		return
+	}
+	visible := m.indicesByQuadrant(m.quadrant)
+	target := m.selected + delta
+	if len(visible) == 0 || target < 0 || target >= len(visible) {
+		return
+	}
+	a, b := visible[m.selected], visible[target]
+	if m.tasks[a].Pinned || m.tasks[b].Pinned {
+		m.SetStatus("The pinned task always comes first; unpin it with [p]", true)
+		return
+	}
+	m.tasks[a].Order, m.tasks[b].Order = m.tasks[b].Order, m.tasks[a].Order
+	m.selected = target
+	m.saveTasks()
+}
+
+// togglePin unpins any other task.
+func (m *Model) togglePin(idx int) {
+	if m.tasks[idx].Pinned {
+		before := m.tasks[idx]
+		m.tasks[idx].Pinned = false
+		m.recordChanges(idx, before)
+		m.saveTasks()
+		m.SetStatus("Unpinned", false)
+		return
+	}
+	for i := range m.tasks {
+		if m.tasks[i].Pinned {
+			before := m.tasks[i]
+			m.tasks[i].Pinned = false
+			m.recordChanges(i, before)
+		}
+	}
+	before := m.tasks[idx]
+	m.tasks[idx].Pinned = true
+	m.recordChanges(idx, before)
+	m.saveTasks()
+	m.SetStatus("Pinned as the one thing", false)
+}
+
+func (m *Model) selectTask(id string) {
+	for i, idx := range m.visibleIndices() {
+		if m.tasks[idx].ID == id {
+			m.selected = i
+			return
+		}
+	}
+}
+
+// reclassify reports the quadrant fields the task still needs.
+func (m *Model) reclassify(idx, q int) {
+	if engine.QuadrantIndex(m.tasks[idx]) == q {
+		return
+	}
+	before := m.tasks[idx]
+	moved := engine.SetQuadrant(before, q)
+	if !moved.Urgent && engine.ApplyUrgency(moved, time.Now()).Urgent {
+		m.SetStatus("Due within 24h keeps the task urgent; change the due date first", true)
+		return
+	}
+	m.tasks[idx] = moved
+	m.recordChanges(idx, before)
+	m.saveTasks()
+	if m.statusIsErr {
+		return
+	}
+	if !m.grouped() {
+		m.quadrant = q
+	}
+	m.selectTask(moved.ID)
+
+	msg := "Moved to " + engine.Quadrant(moved)
+	if missing := engine.MissingFields(moved); len(missing) > 0 {
+		msg += "; missing " + strings.Join(missing, ", ")
+	}
+	m.SetStatus(msg, false)
+}
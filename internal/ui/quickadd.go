@@ -0,0 +1,40 @@
+package ui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/mrbooshehri/actNow/internal/engine"
+	"github.com/mrbooshehri/actNow/internal/quickadd"
+)
+
+// quickAdd prompts for a task on one line in the quick-add syntax.
+func (m *Model) quickAdd() tea.Cmd {
+	return m.prompt("Quick add", "Title !imp !urg @due:+2h #tag >delegate ~effort", "", func(m *Model, value string) {
+		if value == "" {
+			return
+		}
+		now := time.Now()
+		task, err := quickadd.Parse(value, now)
+		if err == nil && task.EffortEstimate != "" {
+			_, err = engine.ParseEffort(task.EffortEstimate, m.cfg.Points())
+		}
+		if err != nil {
+			m.setStatusErr("Quick add: " + err.Error())
+			return
+		}
+		task = engine.ApplyUrgency(task, now)
+		task.Order = engine.NextOrder(m.tasks)
+		m.tasks = append(m.tasks, task)
+		m.saveTasks()
+		if m.statusIsErr {
+			return
+		}
+		if !m.grouped() {
+			m.quadrant = engine.QuadrantIndex(task)
+		}
+		m.selectTask(task.ID)
+		m.SetStatus("Added to "+engine.Quadrant(task)+": "+quickadd.Format(task), false)
+	})
+}
@@ -0,0 +1,281 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/mrbooshehri/actNow/internal/engine"
+	"github.com/mrbooshehri/actNow/internal/format"
+	"github.com/mrbooshehri/actNow/internal/model"
+	"github.com/mrbooshehri/actNow/internal/store"
+)
+
+// startReview keeps the queue by ID so eliminated tasks don't shift it.
+func (m Model) startReview() (tea.Model, tea.Cmd) {
+	m.reviewIDs = nil
+	for _, idx := range engine.ReviewQueue(m.tasks, time.Now()) {
+		m.reviewIDs = append(m.reviewIDs, m.tasks[idx].ID)
+	}
+	m.reviewPos = 0
+	m.reviewOutcomes = map[string]int{}
+	if len(m.reviewIDs) == 0 {
+		m.finishReview()
+		if !m.statusIsErr {
+			m.SetStatus("Nothing pending to review; today's review is done", false)
+		}
+		return m, nil
+	}
+	m.mode = modeReview
+	m.reviewFinished = false
+	return m, nil
+}
+
+// reviewTask skips tasks that no longer exist.
+func (m *Model) reviewTask() (int, bool) {
+	for m.reviewPos < len(m.reviewIDs) {
+		for i, t := range m.tasks {
+			if t.ID == m.reviewIDs[m.reviewPos] {
+				return i, true
+			}
+		}
+		m.reviewPos++
+	}
+	return 0, false
+}
+
+func (m Model) updateReview(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.statusMsg = ""
+	m.statusIsErr = false
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc", "q":
+		if !m.reviewFinished {
+			left := len(m.reviewIDs) - m.reviewPos
+			m.SetStatus("Review stopped with "+format.Plural(left, "task", "tasks")+" left; it was not recorded", true)
+		}
+		m.mode = modeList
+		return m, nil
+	}
+	if m.reviewFinished {
+		m.mode = modeList
+		return m, nil
+	}
+	idx, ok := m.reviewTask()
+	if !ok {
+		m.finishReview()
+		return m, nil
+	}
+	task := m.tasks[idx]
+	id := task.ID
+
+	switch key := msg.String(); key {
+	case "enter", "c":
+		m.updateTaskByID(id, "", func(t *model.Task) {
+			if engine.Resurfaced(*t, time.Now()) {
+				t.Status = model.StatusPending
+			}
+		})
+		m.reviewNext("carried forward")
+	case "1", "2", "3", "4":
+		q := int(key[0] - '1')
+		if engine.QuadrantIndex(task) == q {
+			m.reviewNext("carried forward")
+			return m, nil
+		}
+		moved := engine.SetQuadrant(task, q)
+		if !moved.Urgent && engine.ApplyUrgency(moved, time.Now()).Urgent {
+			m.SetStatus("Due within 24h keeps the task urgent; change the due date first", true)
+			return m, nil
+		}
+		m.updateTaskByID(id, "", func(t *model.Task) { *t = moved })
+		m.reviewNext("reclassified")
+	case "f":
+		return m, m.prompt("Defer", "Defer "+m.describe([]int{idx})+" until (e.g. next mon, +3d, 2024-03-08):", "", func(m *Model, value string) {
+			at, err := parseOptionalDate(value, time.Now())
+			if err == nil && at == nil {
+				err = fmt.Errorf("a date is required to defer")
+			}
+			if err != nil {
+				m.setStatusErr("Defer: " + err.Error())
+				return
+			}
+			m.updateTaskByID(id, "", func(t *model.Task) {
+				t.Status = model.StatusDeferred
+				t.PlannedDate = at
+			})
+			m.reviewNext("deferred")
+		})
+	case "D":
+		return m, m.prompt("Delegate", "Delegate "+m.describe([]int{idx})+" to:", task.DelegateTo, func(m *Model, value string) {
+			if value == "" {
+				m.setStatusErr("A name is required to delegate")
+				return
+			}
+			m.updateTaskByID(id, "", func(t *model.Task) { t.DelegateTo = value })
+			m.reviewNext("delegated")
+		})
+	case "x":
+		return m, m.prompt("Eliminate", "Why drop "+m.describe([]int{idx})+"? The reason is kept with it.", task.DeleteReason, func(m *Model, value string) {
+			if value == "" {
+				m.setStatusErr("A reason is required to eliminate a task")
+				return
+			}
+			m.setDeleteReason(idx, value)
+			m.moveToTrash([]int{idx}, true)
+			if !m.statusIsErr {
+				m.reviewNext("eliminated")
+			}
+		})
+	case "d":
+		m.updateTaskByID(id, "", func(t *model.Task) { t.Status = model.StatusDone })
+		m.reviewNext("done")
+	}
+	return m, nil
+}
+
+func (m *Model) reviewNext(outcome string) {
+	if m.statusIsErr {
+		return
+	}
+	m.reviewOutcomes[outcome]++
+	m.reviewPos++
+	if _, ok := m.reviewTask(); !ok {
+		m.finishReview()
+	}
+}
+
+// finishReview records the review in the reviews log.
+func (m *Model) finishReview() {
+	reviews, ok := m.loadReviews()
+	if !ok {
+		return
+	}
+	reviews = append(reviews, model.Review{Kind: model.ReviewDaily, At: time.Now(), Tasks: m.reviewPos, Outcomes: m.reviewOutcomes})
+	if m.saveReviews(reviews) {
+		m.reviewFinished = true
+		m.SetStatus("", false)
+	}
+}
+
+func (m *Model) loadReviews() ([]model.Review, bool) {
+	data, err := m.store.LoadReviews()
+	if err != nil {
+		m.setStatusErr("Failed to load reviews")
+		return nil, false
+	}
+	var reviews []model.Review
+	if err := store.DecodeTasks(data, &reviews); err != nil {
+		m.setStatusErr("Reviews file is corrupted")
+		return nil, false
+	}
+	return reviews, true
+}
+
+func (m *Model) saveReviews(reviews []model.Review) bool {
+	data, err := store.EncodeTasks(reviews)
+	if err != nil {
+		m.setStatusErr("Failed to encode reviews")
+		return false
+	}
+	if err := m.store.SaveReviews(data); err != nil {
+		m.setStatusErr("Failed to save reviews")
+		return false
+	}
+	return true
+}
+
+// outcomeLines lists the review outcomes, most frequent first.
+func outcomeLines(outcomes map[string]int) []string {
+	names := make([]string, 0, len(outcomes))
+	for name := range outcomes {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if outcomes[names[i]] != outcomes[names[j]] {
+			return outcomes[names[i]] > outcomes[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	lines := make([]string, 0, len(names))
+	for _, name := range names {
+		lines = append(lines, fmt.Sprintf("%3d  %s", outcomes[name], name))
+	}
+	return lines
+}
+
+func (m Model) reviewLines(width int) []string {
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Bold(true)
+	if m.reviewFinished {
+		lines := []string{labelStyle.Render("Review done: " + format.Plural(m.reviewPos, "task", "tasks") + " reviewed"), ""}
+		return append(lines, outcomeLines(m.reviewOutcomes)...)
+	}
+	idx, ok := m.reviewTask()
+	if !ok {
+		return []string{"Nothing left to review."}
+	}
+	task := m.tasks[idx]
+	now := time.Now()
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+	var lines []string
+	switch {
+	case engine.Overdue(task, now):
+		lines = append(lines, escalateStyle.Render("OVERDUE"))
+	case engine.Resurfaced(task, now):
+		lines = append(lines, labelStyle.Render("Back from deferral"))
+	}
+	lines = append(lines, m.taskLines(idx, true, nil, width)...)
+	lines = append(lines, "")
+	field := func(label, value string) {
+		if value != "" {
+			lines = append(lines, fitLine(dim.Render(label+": ")+value, width))
+		}
+	}
+	field("Quadrant", engine.Quadrant(task))
+	field("Due", formatDue(task.DueAt, now))
+	field("Planned", formatDate(task.PlannedDate))
+	field("Next action", task.NextAction)
+	field("Effort", m.formatEffort(task.EffortEstimate))
+	field("Delegate to", task.DelegateTo)
+	if missing := engine.MissingFields(task); len(missing) > 0 {
+		field("Missing", strings.Join(missing, ", "))
+	}
+	return lines
+}
+
+func (m Model) viewReview() string {
+	width, height := m.width, m.height
+	if width == 0 || height == 0 {
+		width, height = 80, 24
+	}
+	footer := "[enter] carry forward  [1-4] move  [f] defer  [D] delegate  [x] eliminate  [d] done  [esc] stop"
+	title := fmt.Sprintf("DAILY REVIEW — %d/%d", min(m.reviewPos+1, len(m.reviewIDs)), len(m.reviewIDs))
+	if m.reviewFinished {
+		footer = "[any key] back"
+		title = "DAILY REVIEW — DONE"
+	}
+	extra := []string{}
+	if m.statusMsg != "" {
+		extra = append(extra, m.statusLine())
+	}
+	boxH := max(height-1-len(extra), 3)
+	lines := m.reviewLines(width - 2)
+	if maxLines := boxH - 2; len(lines) > maxLines {
+		lines = lines[:maxLines]
+	}
+
+	borderColor, _ := quadrantColors(0)
+	border := lipgloss.ThickBorder()
+	borderStyle := lipgloss.NewStyle().Foreground(borderColor)
+	textStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("255"))
+	box := renderPanelBox(border, borderStyle, textStyle, width, boxH, title, strings.Join(lines, "\n"))
+	footerStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+	parts := append([]string{box}, extra...)
+	parts = append(parts, footerStyle.Render(fitLine(footer, width)))
+	return lipgloss.JoinVertical(lipgloss.Left, parts...)
+}
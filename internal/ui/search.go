@@ -0,0 +1,300 @@
+package ui
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/mrbooshehri/actNow/internal/format"
+	"github.com/mrbooshehri/actNow/internal/model"
+	"github.com/mrbooshehri/actNow/internal/query"
+)
+
+var matchStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("0")).Background(lipgloss.Color("220"))
+
+type searchField struct {
+	label string
+	text  string
+}
+
+// searchHit holds title highlights or a snippet from another matching field.
+type searchHit struct {
+	titlePositions []int
+	field          string
+	text           string
+	positions      []int
+}
+
+func searchFields(t model.Task) []searchField {
+	return []searchField{
+		{label: "title", text: t.Title},
+		{label: "description", text: t.Description},
+		{label: "impact", text: t.Impact},
+		{label: "next action", text: t.NextAction},
+		{label: "delegate", text: t.DelegateTo},
+		{label: "tags", text: formatTags(t.Tags)},
+	}
+}
+
+func searchHighlights(t model.Task, terms []string) searchHit {
+	hit := searchHit{}
+	if len(terms) == 0 {
+		return hit
+	}
+	fields := searchFields(t)
+	positions := make([][]int, len(fields))
+	for _, term := range terms {
+		for i, f := range fields {
+			if pos, ok := fuzzyMatch(term, f.text); ok {
+				positions[i] = append(positions[i], pos...)
+			}
+		}
+	}
+	hit.titlePositions = positions[0]
+	for i := 1; i < len(fields); i++ {
+		if len(positions[i]) > 0 {
+			hit.field = fields[i].label
+			hit.text = fields[i].text
+			hit.positions = positions[i]
+			break
+		}
+	}
+	return hit
+}
+
+// fuzzyText is the free-text matcher for TUI queries.
+func fuzzyText(t model.Task, term string) bool {
+	for _, f := range searchFields(t) {
+		if _, ok := fuzzyMatch(term, f.text); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// fuzzyMatch prefers a substring, else matches the runes in order.
+func fuzzyMatch(pattern, text string) ([]int, bool) {
+	p := []rune(strings.ToLower(pattern))
+	t := []rune(strings.ToLower(text))
+	if len(p) == 0 {
+		return nil, true
+	}
+	if start := runeIndex(t, p); start >= 0 {
+		out := make([]int, len(p))
+		for i := range p {
+			out[i] = start + i
+		}
+		return out, true
+	}
+	out := make([]int, 0, len(p))
+	j := 0
+	for i := 0; i < len(t) && j < len(p); i++ {
+		if t[i] == p[j] {
+			out = append(out, i)
+			j++
+		}
+	}
+	if j < len(p) {
+		return nil, false
+	}
+	return out, true
+}
+
+func runeIndex(haystack, needle []rune) int {
+	for i := 0; i+len(needle) <= len(haystack); i++ {
+		match := true
+		for j := range needle {
+			if haystack[i+j] != needle[j] {
+				match = false
+				break
+			}
+		}
+		if match {
+			return i
+		}
+	}
+	return -1
+}
+
+func highlight(text string, positions []int) string {
+	if len(positions) == 0 {
+		return text
+	}
+	marked := make(map[int]bool, len(positions))
+	for _, p := range positions {
+		marked[p] = true
+	}
+	var b strings.Builder
+	for i, r := range []rune(text) {
+		if marked[i] {
+			b.WriteString(matchStyle.Render(string(r)))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// snippet shifts positions to the excerpt.
+func snippet(text string, positions []int, width int) (string, []int) {
+	runes := []rune(strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return ' '
+		}
+		return r
+	}, text))
+	if width < 8 || len(runes) <= width || len(positions) == 0 {
+		if len(runes) > width && width > 0 {
+			runes = runes[:width]
+		}
+		return string(runes), positions
+	}
+	start := positions[0] - width/3
+	if start < 0 {
+		start = 0
+	}
+	if start+width > len(runes) {
+		start = len(runes) - width
+	}
+	shifted := make([]int, 0, len(positions))
+	for _, p := range positions {
+		if p >= start && p < start+width {
+			shifted = append(shifted, p-start)
+		}
+	}
+	return string(runes[start : start+width]), shifted
+}
+
+func newSearchInput() textinput.Model {
+	ti := textinput.New()
+	ti.Prompt = "/"
+	ti.Placeholder = "text or filters, e.g. db tag:ops due<48h"
+	ti.CharLimit = 200
+	return ti
+}
+
+func (m Model) startSearch() (tea.Model, tea.Cmd) {
+	m.searching = true
+	m.searchInput = newSearchInput()
+	m.searchInput.SetValue(m.searchQuery)
+	m.searchInput.SetCursor(len([]rune(m.searchQuery)))
+	return m, m.searchInput.Focus()
+}
+
+func (m Model) updateSearch(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc":
+		m.searching = false
+		m.searchInput.Blur()
+		m.setSearch("")
+		return m, nil
+	case "enter":
+		m.searching = false
+		m.searchInput.Blur()
+		if m.searchErr != "" {
+			m.setSearch(m.searchFilter.String())
+		}
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.searchInput, cmd = m.searchInput.Update(msg)
+	m.setSearch(m.searchInput.Value())
+	return m, cmd
+}
+
+// setSearch keeps the last valid filter while s does not parse.
+func (m *Model) setSearch(s string) {
+	m.searchQuery = s
+	if strings.TrimSpace(s) == "" {
+		m.searchQuery = ""
+		m.searchFilter = nil
+		m.searchErr = ""
+		m.selectFirstHit()
+		return
+	}
+	q, err := query.Parse(s)
+	if err != nil {
+		m.searchErr = err.Error()
+		return
+	}
+	m.searchErr = ""
+	m.searchFilter = q.WithText(fuzzyText)
+	m.selectFirstHit()
+}
+
+func (m *Model) selectFirstHit() {
+	if m.grouped() {
+		m.selected = 0
+		return
+	}
+	if len(m.indicesByQuadrant(m.quadrant)) > 0 {
+		m.selected = 0
+		return
+	}
+	for q := 0; q < 4; q++ {
+		if len(m.indicesByQuadrant(q)) > 0 {
+			m.quadrant = q
+			m.selected = 0
+			return
+		}
+	}
+	m.selected = 0
+}
+
+// jumpHit wraps around across quadrants.
+func (m *Model) jumpHit(delta int) {
+	if m.grouped() {
+		if n := len(m.groupedIndices()); n > 0 {
+			m.selected = (m.selected + delta + n) % n
+		}
+		return
+	}
+	type pos struct{ q, i int }
+	var hits []pos
+	current := -1
+	for q := 0; q < 4; q++ {
+		for i := range m.indicesByQuadrant(q) {
+			if q == m.quadrant && i == m.selected {
+				current = len(hits)
+			}
+			hits = append(hits, pos{q, i})
+		}
+	}
+	if len(hits) == 0 {
+		return
+	}
+	next := 0
+	if current >= 0 {
+		next = (current + delta + len(hits)) % len(hits)
+	} else if delta < 0 {
+		next = len(hits) - 1
+	}
+	m.quadrant = hits[next].q
+	m.selected = hits[next].i
+}
+
+func (m Model) searchLine() string {
+	errStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	if m.searching {
+		if m.searchErr != "" {
+			return m.searchInput.View() + "  " + errStyle.Render(m.searchErr)
+		}
+		return m.searchInput.View()
+	}
+	count := 0
+	if m.grouped() {
+		count = len(m.groupedIndices())
+	} else {
+		for q := 0; q < 4; q++ {
+			count += len(m.indicesByQuadrant(q))
+		}
+	}
+	style := lipgloss.NewStyle().Foreground(lipgloss.Color("220"))
+	return style.Render("/"+m.searchQuery) + lipgloss.NewStyle().Foreground(lipgloss.Color("245")).Render(
+		"  "+format.Plural(count, "match", "matches")+"  [n/N] next/prev  [/] refine  [esc] clear")
+}
@@ -0,0 +1,93 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/mrbooshehri/actNow/internal/engine"
+	"github.com/mrbooshehri/actNow/internal/format"
+)
+
+// statsRanges are the periods the stats view cycles through, in days.
+var statsRanges = []int{7, 30, 90}
+
+func (m Model) startStats() (tea.Model, tea.Cmd) {
+	m.mode = modeStats
+	m.statsOffset = 0
+	if m.statsDays == 0 {
+		m.statsDays = statsRanges[1]
+	}
+	return m, nil
+}
+
+func (m Model) updateStats(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc", "q", "S":
+		m.mode = modeList
+	case "tab":
+		next := statsRanges[0]
+		for i, d := range statsRanges {
+			if d == m.statsDays && i+1 < len(statsRanges) {
+				next = statsRanges[i+1]
+			}
+		}
+		m.statsDays = next
+		m.statsOffset = 0
+	case "up", "k":
+		m.statsOffset = max(m.statsOffset-1, 0)
+	case "down", "j":
+		m.statsOffset++
+	}
+	m.statsOffset = clamp(m.statsOffset, 0, m.maxStatsOffset())
+	return m, nil
+}
+
+func (m Model) statsSize() (int, int) {
+	width, height := m.width, m.height
+	if width == 0 || height == 0 {
+		width, height = 80, 24
+	}
+	return width, max(height-1, 3)
+}
+
+func (m Model) statsLines(width int) []string {
+	headingStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Bold(true)
+	var lines []string
+	for _, line := range format.StatsLines(engine.ComputeStats(m.tasks, time.Now(), m.statsDays)) {
+		if line != "" && !strings.HasPrefix(line, " ") {
+			line = headingStyle.Render(line)
+		}
+		lines = append(lines, fitLine(line, width))
+	}
+	return lines
+}
+
+func (m Model) maxStatsOffset() int {
+	width, boxH := m.statsSize()
+	return max(len(m.statsLines(width-2))-(boxH-2), 0)
+}
+
+func (m Model) viewStats() string {
+	width, boxH := m.statsSize()
+	footer := "[tab] 7/30/90 days  [j/k] scroll  [esc] back"
+	lines := m.statsLines(width - 2)
+	maxLines := boxH - 2
+	if len(lines) > maxLines {
+		offset := clamp(m.statsOffset, 0, len(lines)-maxLines)
+		lines = lines[offset : offset+maxLines]
+	}
+
+	border := lipgloss.ThickBorder()
+	borderStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+	textStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("255"))
+	title := fmt.Sprintf("STATISTICS — LAST %d DAYS", m.statsDays)
+	box := renderPanelBox(border, borderStyle, textStyle, width, boxH, title, strings.Join(lines, "\n"))
+	footerStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+	return lipgloss.JoinVertical(lipgloss.Left, box, footerStyle.Render(footer))
+}
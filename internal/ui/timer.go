@@ -0,0 +1,197 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/mrbooshehri/actNow/internal/engine"
+	"github.com/mrbooshehri/actNow/internal/format"
+)
+
+type clockTickMsg time.Time
+
+func clockTick() tea.Cmd {
+	return tea.Tick(time.Second, func(t time.Time) tea.Msg { return clockTickMsg(t) })
+}
+
+type pomodoroPhase int
+
+const (
+	pomodoroIdle pomodoroPhase = iota
+	pomodoroWork
+	pomodoroBreak
+)
+
+type pomodoro struct {
+	taskID string
+	phase  pomodoroPhase
+	ends   time.Time
+	rounds int
+}
+
+// tick keeps a single tick loop.
+func (m *Model) tick() tea.Cmd {
+	if m.ticking {
+		return nil
+	}
+	m.ticking = true
+	return clockTick()
+}
+
+func (m Model) needsTick() bool {
+	return m.mode == modeFocus || m.pomodoro.phase != pomodoroIdle || engine.RunningTimer(m.tasks) >= 0
+}
+
+func (m Model) handleTick(now time.Time) (tea.Model, tea.Cmd) {
+	m.ticking = false
+	var cmds []tea.Cmd
+	if m.pomodoro.phase != pomodoroIdle && !now.Before(m.pomodoro.ends) {
+		cmds = append(cmds, m.advancePomodoro(now))
+	}
+	if m.needsTick() {
+		cmds = append(cmds, m.tick())
+	}
+	return m, tea.Batch(cmds...)
+}
+
+func (m Model) bell() tea.Cmd {
+	if m.cfg != nil && m.cfg.PomodoroSilent {
+		return nil
+	}
+	return func() tea.Msg {
+		fmt.Fprint(os.Stderr, "\a")
+		return nil
+	}
+}
+
+// toggleTimer stops any other running timer.
+func (m *Model) toggleTimer(idx int) tea.Cmd {
+	now := time.Now()
+	task := &m.tasks[idx]
+	if task.Running() {
+		spent := task.StopTimer(now)
+		if m.pomodoro.taskID == task.ID {
+			m.pomodoro.phase = pomodoroIdle
+		}
+		m.saveTasks()
+		if !m.statusIsErr {
+			m.SetStatus(fmt.Sprintf("Timer stopped on %q after %s (%s in total)", task.Title, format.Duration(spent), format.Duration(task.TimeSpent(now))), false)
+		}
+		return nil
+	}
+	stopped := engine.StartTimer(m.tasks, idx, now)
+	if stopped >= 0 && m.pomodoro.taskID == m.tasks[stopped].ID {
+		m.pomodoro.phase = pomodoroIdle
+	}
+	m.saveTasks()
+	if !m.statusIsErr {
+		msg := fmt.Sprintf("Timer started on %q", m.tasks[idx].Title)
+		if stopped >= 0 {
+			msg += fmt.Sprintf("; stopped %q", m.tasks[stopped].Title)
+		}
+		m.SetStatus(msg, false)
+	}
+	return m.tick()
+}
+
+func (m *Model) togglePomodoro(idx int) tea.Cmd {
+	now := time.Now()
+	if m.pomodoro.phase != pomodoroIdle {
+		for i := range m.tasks {
+			if m.tasks[i].ID == m.pomodoro.taskID && m.tasks[i].Running() {
+				m.tasks[i].StopTimer(now)
+				m.saveTasks()
+			}
+		}
+		m.pomodoro.phase = pomodoroIdle
+		m.SetStatus("Pomodoro stopped", false)
+		return nil
+	}
+	work, _ := m.cfg.Pomodoro()
+	engine.StartTimer(m.tasks, idx, now)
+	m.saveTasks()
+	m.pomodoro.taskID = m.tasks[idx].ID
+	m.pomodoro.phase = pomodoroWork
+	m.pomodoro.ends = now.Add(work)
+	if !m.statusIsErr {
+		m.SetStatus(fmt.Sprintf("Pomodoro started on %q: %s of work", m.tasks[idx].Title, format.Duration(work)), false)
+	}
+	return m.tick()
+}
+
+// advancePomodoro rings the bell at the end of each phase.
+func (m *Model) advancePomodoro(now time.Time) tea.Cmd {
+	_, rest := m.cfg.Pomodoro()
+	switch m.pomodoro.phase {
+	case pomodoroWork:
+		for i := range m.tasks {
+			if m.tasks[i].ID == m.pomodoro.taskID && m.tasks[i].Running() {
+				m.tasks[i].StopTimer(m.pomodoro.ends)
+				m.saveTasks()
+			}
+		}
+		m.pomodoro.rounds++
+		m.pomodoro.phase = pomodoroBreak
+		m.pomodoro.ends = m.pomodoro.ends.Add(rest)
+		if !m.statusIsErr {
+			m.SetStatus(fmt.Sprintf("Pomodoro %d done; take a %s break", m.pomodoro.rounds, format.Duration(rest)), false)
+		}
+	case pomodoroBreak:
+		m.pomodoro.phase = pomodoroIdle
+		m.SetStatus("Break over; press O to start the next pomodoro", false)
+	}
+	return m.bell()
+}
+
+func (m Model) timerLine() string {
+	now := time.Now()
+	style := lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+	switch m.pomodoro.phase {
+	case pomodoroWork:
+		return style.Render(fmt.Sprintf("Pomodoro %d: %s left on %q", m.pomodoro.rounds+1, formatClock(m.pomodoro.ends.Sub(now)), m.taskTitle(m.pomodoro.taskID)))
+	case pomodoroBreak:
+		return style.Render(fmt.Sprintf("Break: %s left", formatClock(m.pomodoro.ends.Sub(now))))
+	}
+	i := engine.RunningTimer(m.tasks)
+	if i < 0 {
+		return ""
+	}
+	task := m.tasks[i]
+	current := now.Sub(task.TimeEntries[len(task.TimeEntries)-1].Start)
+	return style.Render(fmt.Sprintf("Timer: %q %s (%s in total)", task.Title, formatClock(current), format.Duration(task.TimeSpent(now))))
+}
+
+func (m Model) taskTitle(id string) string {
+	for _, t := range m.tasks {
+		if t.ID == id {
+			return t.Title
+		}
+	}
+	return ""
+}
+
+// timeSpentLine is e.g. "1h30m of 2h (75%)".
+func (m Model) timeSpentLine(idx int) string {
+	task := m.tasks[idx]
+	if len(task.TimeEntries) == 0 {
+		return ""
+	}
+	spent := task.TimeSpent(time.Now())
+	line := format.Duration(spent)
+	if task.EffortEstimate != "" {
+		if est, err := engine.ParseEffort(task.EffortEstimate, m.cfg.Points()); err == nil && est > 0 {
+			line += fmt.Sprintf(" of %s (%d%%)", format.Duration(est), int(spent*100/est))
+			if spent > est {
+				line += ", over estimate"
+			}
+		}
+	}
+	if task.Running() {
+		line += ", timer running"
+	}
+	return line
+}
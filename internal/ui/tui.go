@@ -2,10 +2,12 @@ package ui
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 	"unicode/utf8"
 
+	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -14,8 +16,12 @@ import (
 	"github.com/muesli/reflow/truncate"
 	"github.com/muesli/reflow/wordwrap"
 
+	"github.com/mrbooshehri/actNow/internal/config"
+	"github.com/mrbooshehri/actNow/internal/dateparse"
 	"github.com/mrbooshehri/actNow/internal/engine"
+	"github.com/mrbooshehri/actNow/internal/format"
 	"github.com/mrbooshehri/actNow/internal/model"
+	"github.com/mrbooshehri/actNow/internal/query"
 	"github.com/mrbooshehri/actNow/internal/store"
 )
 
@@ -25,6 +31,16 @@ const (
 	modeList mode = iota
 	modeForm
 	modeHelp
+	modeDetail
+	modeViewPicker
+	modeEliminate
+	modeWaiting
+	modeCapacity
+	modeReview
+	modeWeekly
+	modeAgenda
+	modeFocus
+	modeStats
 )
 
 type formKind int
@@ -40,6 +56,7 @@ type Model struct {
 	formKind          formKind
 	focusIndex        int
 	store             *store.Store
+	cfg               *config.Config
 	tasks             []model.Task
 	selected          int
 	quadrant          int
@@ -54,14 +71,51 @@ type Model struct {
 	status            string
 	duePicker         duePicker
 	plannedPicker     duePicker
+	followUpPicker    duePicker
+	dateInput         textinput.Model
+	calendar          calendar
 	titleInput        textinput.Model
+	descriptionInput  textarea.Model
 	impactInput       textinput.Model
 	nextActionInput   textinput.Model
 	delegateInput     textinput.Model
 	deleteReasonInput textinput.Model
 	effortInput       textinput.Model
+	tagsInput         textinput.Model
+	projectInput      textinput.Model
 	helpOffset        int
+	detailOffset      int
 	formEditing       bool
+	searching         bool
+	searchInput       textinput.Model
+	searchQuery       string
+	searchFilter      *query.Query
+	searchErr         string
+	activeView        int
+	viewFilter        *query.Query
+	pickerIndex       int
+	marked            map[string]bool
+	visualAnchor      int
+	dialog            *dialog
+	formSnapshot      string
+	eliminateIndex    int
+	eliminateReport   bool
+	trash             []model.TrashEntry
+	trashErr          string
+	waitingIndex      int
+	capacityOffset    int
+	reviewIDs         []string
+	reviewPos         int
+	reviewOutcomes    map[string]int
+	reviewFinished    bool
+	weeklyIndex       int
+	weeklyPlanned     int
+	agendaIndex       int
+	focusSkipped      []string
+	ticking           bool
+	pomodoro          pomodoro
+	statsDays         int
+	statsOffset       int
 }
 
 type formField int
@@ -69,6 +123,7 @@ type formField int
 const (
 	fieldStatus formField = iota
 	fieldTitle
+	fieldDescription
 	fieldImportant
 	fieldUrgent
 	fieldDue
@@ -77,7 +132,10 @@ const (
 	fieldPlanned
 	fieldEffort
 	fieldDelegate
+	fieldFollowUp
 	fieldDeleteReason
+	fieldTags
+	fieldProject
 )
 
 type duePicker struct {
@@ -92,14 +150,19 @@ var statusOptions = []string{
 	model.StatusDeferred,
 }
 
-func New(store *store.Store, tasks []model.Task) Model {
+func New(store *store.Store, cfg *config.Config, tasks []model.Task) Model {
 	m := Model{
-		mode:     modeList,
-		store:    store,
-		tasks:    tasks,
-		selected: 0,
-		quadrant: 0,
-	}
+		mode:         modeList,
+		store:        store,
+		cfg:          cfg,
+		tasks:        tasks,
+		selected:     0,
+		quadrant:     0,
+		activeView:   -1,
+		visualAnchor: -1,
+	}
+	engine.NormalizeOrder(m.tasks)
+	m.ticking = engine.RunningTimer(m.tasks) >= 0
 	return m
 }
 
@@ -109,6 +172,9 @@ func (m *Model) SetStatus(msg string, isErr bool) {
 }
 
 func (m Model) Init() tea.Cmd {
+	if m.ticking {
+		return clockTick()
+	}
 	return nil
 }
 
@@ -120,15 +186,49 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.width = msg.Width
 		m.height = msg.Height
 		m.helpOffset = 0
+		if m.mode == modeForm {
+			m.sizeDescription()
+		}
+		m.sizeDialog()
 		return m, nil
+	case clockTickMsg:
+		return m.handleTick(time.Time(msg))
+	case editorFinishedMsg:
+		return m.handleEditorFinished(msg)
 	case tea.KeyMsg:
+		if m.dialog != nil {
+			return m.updateDialog(msg)
+		}
 		switch m.mode {
 		case modeList:
+			if m.searching {
+				return m.updateSearch(msg)
+			}
 			return m.updateList(msg)
 		case modeForm:
 			return m.updateForm(msg)
 		case modeHelp:
 			return m.updateHelp(msg)
+		case modeDetail:
+			return m.updateDetail(msg)
+		case modeViewPicker:
+			return m.updateViewPicker(msg)
+		case modeEliminate:
+			return m.updateEliminate(msg)
+		case modeWaiting:
+			return m.updateWaiting(msg)
+		case modeCapacity:
+			return m.updateCapacity(msg)
+		case modeReview:
+			return m.updateReview(msg)
+		case modeWeekly:
+			return m.updateWeekly(msg)
+		case modeAgenda:
+			return m.updateAgenda(msg)
+		case modeFocus:
+			return m.updateFocus(msg)
+		case modeStats:
+			return m.updateStats(msg)
 		}
 	}
 
@@ -136,6 +236,13 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m Model) View() string {
+	if m.dialog != nil {
+		return m.viewDialog(m.viewMode())
+	}
+	return m.viewMode()
+}
+
+func (m Model) viewMode() string {
 	switch m.mode {
 	case modeList:
 		return m.viewList()
@@ -143,6 +250,26 @@ func (m Model) View() string {
 		return m.viewOverlayForm()
 	case modeHelp:
 		return m.viewHelp()
+	case modeDetail:
+		return m.viewDetail()
+	case modeViewPicker:
+		return m.viewPickerOverlay()
+	case modeEliminate:
+		return m.viewEliminate()
+	case modeWaiting:
+		return m.viewWaiting()
+	case modeCapacity:
+		return m.viewCapacity()
+	case modeReview:
+		return m.viewReview()
+	case modeWeekly:
+		return m.viewWeekly()
+	case modeAgenda:
+		return m.viewAgenda()
+	case modeFocus:
+		return m.viewFocus()
+	case modeStats:
+		return m.viewStats()
 	default:
 		return ""
 	}
@@ -157,10 +284,32 @@ func (m *Model) applyUrgency() {
 
 func (m Model) updateList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	visible := m.visibleIndices()
+	m.statusMsg = ""
+	m.statusIsErr = false
 
 	switch msg.String() {
 	case "ctrl+c", "q":
 		return m, tea.Quit
+	case "/":
+		return m.startSearch()
+	case "n", "N":
+		if m.searchQuery != "" {
+			if msg.String() == "n" {
+				m.jumpHit(1)
+			} else {
+				m.jumpHit(-1)
+			}
+		}
+	case "esc":
+		if m.hasSelection() {
+			m.clearSelection()
+		} else if m.searchQuery != "" {
+			m.setSearch("")
+		}
+	case " ":
+		m.toggleMark(visible)
+	case "V":
+		m.toggleVisual()
 	case "h":
 		m.prevMode = m.mode
 		m.mode = modeHelp
@@ -175,14 +324,76 @@ func (m Model) updateList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.selected++
 		}
 	case "tab":
-		m.quadrant = (m.quadrant + 1) % 4
-		m.selected = 0
+		m.commitVisual()
+		m.nextSection(1)
 	case "shift+tab":
-		m.quadrant = (m.quadrant + 3) % 4
-		m.selected = 0
+		m.commitVisual()
+		m.nextSection(-1)
+	case "K", "shift+up":
+		m.moveTask(-1)
+	case "J", "shift+down":
+		m.moveTask(1)
+	case "p":
+		if len(visible) == 0 {
+			return m, nil
+		}
+		idx := visible[m.selected]
+		id := m.tasks[idx].ID
+		m.togglePin(idx)
+		m.selectTask(id)
+	case "1", "2", "3", "4", "I", "U":
+		if len(visible) == 0 {
+			return m, nil
+		}
+		idx := visible[m.selected]
+		q := engine.QuadrantIndex(m.tasks[idx])
+		if m.hasSelection() {
+			targets := m.targets(visible)
+			switch key := msg.String(); key {
+			case "I":
+				m.confirm("Toggle", "Toggle importance of "+m.describe(targets)+"?", false, func(m *Model, _ string) {
+					m.bulkToggle(targets, 2, "importance toggled")
+				})
+			case "U":
+				m.confirm("Toggle", "Toggle urgency of "+m.describe(targets)+"?", false, func(m *Model, _ string) {
+					m.bulkToggle(targets, 1, "urgency toggled")
+				})
+			default:
+				q := int(key[0] - '1')
+				m.confirm("Move", "Move "+m.describe(targets)+" to "+engine.Quadrant(engine.SetQuadrant(model.Task{}, q))+"?", false, func(m *Model, _ string) {
+					m.bulkReclassify(targets, q)
+				})
+			}
+			return m, nil
+		}
+		// Quadrant bits: 2 is "not important", 1 is "not urgent".
+		switch key := msg.String(); key {
+		case "I":
+			q ^= 2
+		case "U":
+			q ^= 1
+		default:
+			q = int(key[0] - '1')
+		}
+		m.reclassify(idx, q)
+	case "s":
+		m.cycleSort()
+	case "v":
+		m.mode = modeViewPicker
+		m.pickerIndex = m.activeView + 1
+		return m, nil
 	case "a":
 		m.startForm(formAdd, model.Task{})
 		return m, m.focusCmd()
+	case "A":
+		return m, m.quickAdd()
+	case "enter":
+		if len(visible) == 0 {
+			return m, nil
+		}
+		m.mode = modeDetail
+		m.detailOffset = 0
+		return m, nil
 	case "e":
 		if len(visible) == 0 {
 			return m, nil
@@ -190,33 +401,92 @@ func (m Model) updateList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		idx := visible[m.selected]
 		m.startForm(formEdit, m.tasks[idx])
 		return m, m.focusCmd()
-	case "d":
+	case "E":
+		if len(visible) == 0 {
+			return m, nil
+		}
+		return m, m.openInEditor(visible[m.selected])
+	case "d", "f":
 		if len(visible) == 0 {
 			return m, nil
 		}
+		status, verb := model.StatusDone, "marked done"
+		if msg.String() == "f" {
+			status, verb = model.StatusDeferred, "deferred"
+		}
+		if m.hasSelection() {
+			targets := m.targets(visible)
+			m.confirm("Confirm", "Mark "+m.describe(targets)+" "+status+"?", false, func(m *Model, _ string) {
+				m.bulkStatus(targets, status, verb)
+			})
+			return m, nil
+		}
 		idx := visible[m.selected]
-		if m.tasks[idx].Status == model.StatusDone {
+		before := m.tasks[idx]
+		if m.tasks[idx].Status == status {
 			m.tasks[idx].Status = model.StatusPending
 		} else {
-			m.tasks[idx].Status = model.StatusDone
+			m.tasks[idx].Status = status
 		}
+		m.recordChanges(idx, before)
 		m.saveTasks()
 	case "x":
+		targets := m.targets(visible)
+		if len(targets) == 0 {
+			return m, nil
+		}
+		m.confirm("Delete", "Move "+m.describe(targets)+" to the trash?", false, func(m *Model, _ string) {
+			m.moveToTrash(targets, false)
+		})
+	case "P":
+		m.purgeTrash()
+	case "X":
+		return m.startEliminate()
+	case "w":
+		return m.startWaiting()
+	case "r":
+		return m.startReview()
+	case "W":
+		return m.startWeekly()
+	case "F":
+		return m, m.startFocus()
+	case "S":
+		return m.startStats()
+	case "g":
+		m.mode = modeAgenda
+		m.agendaIndex = 0
+		return m, nil
+	case "c":
+		m.mode = modeCapacity
+		m.capacityOffset = 0
+		return m, nil
+	case "t":
 		if len(visible) == 0 {
 			return m, nil
 		}
-		idx := visible[m.selected]
-		m.tasks = append(m.tasks[:idx], m.tasks[idx+1:]...)
-		if m.selected > 0 && m.selected >= len(visible)-1 {
-			m.selected--
+		return m, m.tagTargets(m.targets(visible))
+	case "T", "O":
+		if len(visible) == 0 {
+			return m, nil
 		}
-		m.saveTasks()
+		if msg.String() == "O" {
+			return m, m.togglePomodoro(visible[m.selected])
+		}
+		return m, m.toggleTimer(visible[m.selected])
+	case "D":
+		if len(visible) == 0 {
+			return m, nil
+		}
+		return m, m.delegateTargets(m.targets(visible))
 	}
 
 	return m, nil
 }
 
 func (m Model) updateForm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.calendar.open {
+		return m.updateCalendar(msg)
+	}
 	fields := m.formFields()
 	if len(fields) == 0 {
 		return m, nil
@@ -232,6 +502,18 @@ func (m Model) updateForm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.formEditing = false
 			return m, m.focusCmd()
 		}
+		if current == fieldDescription {
+			m.descriptionInput, _ = m.descriptionInput.Update(msg)
+			return m, nil
+		}
+		if p := m.pickerFor(current); p != nil {
+			if msg.String() == "enter" {
+				m.applyDateInput(p)
+				return m, nil
+			}
+			m.dateInput, _ = m.dateInput.Update(msg)
+			return m, nil
+		}
 		if input := m.inputFor(current); input != nil {
 			*input, _ = input.Update(msg)
 			input.SetCursor(len([]rune(input.Value())))
@@ -245,6 +527,12 @@ func (m Model) updateForm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.formEditing = false
 			return m, m.focusCmd()
 		}
+		if m.formState() != m.formSnapshot {
+			m.confirm("Discard changes", "Close the form and discard your changes?", false, func(m *Model, _ string) {
+				m.mode = modeList
+			})
+			return m, nil
+		}
 		m.mode = modeList
 		return m, nil
 	case "up", "k", "shift+tab":
@@ -270,6 +558,16 @@ func (m Model) updateForm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.formEditing = true
 			return m, m.focusCmd()
 		}
+		if m.pickerFor(current) != nil {
+			m.formEditing = true
+			m.dateInput = newInput("tomorrow 9:00, fri, +3d, in 2h, next monday, eod, eow, 2024-03-08", "")
+			return m, m.dateInput.Focus()
+		}
+	case "c":
+		if m.pickerFor(current) != nil {
+			m.openCalendar(current)
+			return m, nil
+		}
 	case " ":
 		if current == fieldImportant {
 			m.important = !m.important
@@ -298,6 +596,12 @@ func (m Model) updateForm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 	}
+
+	if current == fieldFollowUp {
+		if m.handleDatePicker(&m.followUpPicker, msg.String()) {
+			return m, nil
+		}
+	}
 	return m, nil
 }
 
@@ -329,13 +633,18 @@ func (m *Model) startForm(kind formKind, task model.Task) {
 	m.editTaskID = task.ID
 	m.focusIndex = 0
 	m.formEditing = false
+	m.calendar = calendar{}
 
 	m.titleInput = newInput("Title", task.Title)
+	m.descriptionInput = newTextArea("Description", task.Description)
+	m.sizeDescription()
 	m.impactInput = newInput("Impact", task.Impact)
 	m.nextActionInput = newInput("Next Action", task.NextAction)
 	m.delegateInput = newInput("Delegate To", task.DelegateTo)
 	m.deleteReasonInput = newInput("Delete Reason", task.DeleteReason)
 	m.effortInput = newInput("Effort Estimate", task.EffortEstimate)
+	m.tagsInput = newInput("ops, infra", strings.Join(task.Tags, ", "))
+	m.projectInput = newInput("Project", task.Project)
 	if kind == formAdd {
 		m.important = true
 		m.urgent = true
@@ -351,7 +660,21 @@ func (m *Model) startForm(kind formKind, task model.Task) {
 	}
 	m.duePicker = newDuePicker(task.DueAt)
 	m.plannedPicker = newDuePicker(task.PlannedDate)
+	m.followUpPicker = newDuePicker(task.FollowUpAt)
 	m.focusIndex = m.indexOfField(fieldTitle)
+	m.formSnapshot = m.formState()
+}
+
+// formState summarizes the form values so unsaved changes can be detected.
+func (m Model) formState() string {
+	values := []string{m.descriptionInput.Value(), m.status, fmt.Sprint(m.important, m.urgent)}
+	for _, input := range m.allInputs() {
+		values = append(values, input.Value())
+	}
+	for _, p := range []duePicker{m.duePicker, m.plannedPicker, m.followUpPicker} {
+		values = append(values, fmt.Sprint(p.enabled, p.t.Unix()))
+	}
+	return strings.Join(values, "\x00")
 }
 
 func newInput(placeholder, value string) textinput.Model {
@@ -364,11 +687,32 @@ func newInput(placeholder, value string) textinput.Model {
 	return ti
 }
 
+func newTextArea(placeholder, value string) textarea.Model {
+	ta := textarea.New()
+	ta.Prompt = ""
+	ta.Placeholder = placeholder
+	ta.ShowLineNumbers = false
+	ta.CharLimit = 4000
+	ta.SetHeight(5)
+	ta.FocusedStyle.CursorLine = lipgloss.NewStyle()
+	ta.FocusedStyle.Text = lipgloss.NewStyle().Foreground(lipgloss.Color("255"))
+	ta.BlurredStyle.Text = lipgloss.NewStyle().Foreground(lipgloss.Color("255"))
+	ta.SetValue(value)
+	return ta
+}
+
 func (m *Model) focusCmd() tea.Cmd {
 	for _, input := range m.allInputs() {
 		input.Blur()
 	}
+	m.descriptionInput.Blur()
 	if field := m.currentField(); field != nil {
+		if *field == fieldDescription {
+			if m.formEditing {
+				return m.descriptionInput.Focus()
+			}
+			return nil
+		}
 		if m.formEditing {
 			if input := m.inputFor(*field); input != nil {
 				input.Focus()
@@ -382,7 +726,7 @@ func (m *Model) focusCmd() tea.Cmd {
 
 func (m Model) submitForm() tea.Model {
 	title := strings.TrimSpace(m.titleInput.Value())
-	desc := ""
+	desc := strings.TrimSpace(m.descriptionInput.Value())
 	var due *time.Time
 	if m.duePicker.enabled {
 		due = &m.duePicker.t
@@ -391,11 +735,22 @@ func (m Model) submitForm() tea.Model {
 	if m.plannedPicker.enabled {
 		planned = &m.plannedPicker.t
 	}
+	var followUp *time.Time
+	if m.followUpPicker.enabled {
+		followUp = &m.followUpPicker.t
+	}
 
 	if title == "" {
 		m.setStatusErr("Title is required")
 		return m
 	}
+	if effort := strings.TrimSpace(m.effortInput.Value()); effort != "" && slices.Contains(m.formFields(), fieldEffort) {
+		if _, err := engine.ParseEffort(effort, m.cfg.Points()); err != nil {
+			m.setStatusErr("Effort: " + err.Error())
+			m.focusIndex = m.indexOfField(fieldEffort)
+			return m
+		}
+	}
 
 	switch m.formKind {
 	case formAdd:
@@ -405,13 +760,19 @@ func (m Model) submitForm() tea.Model {
 		task.NextAction = strings.TrimSpace(m.nextActionInput.Value())
 		task.PlannedDate = planned
 		task.DelegateTo = strings.TrimSpace(m.delegateInput.Value())
+		task.FollowUpAt = followUp
 		task.DeleteReason = strings.TrimSpace(m.deleteReasonInput.Value())
 		task.EffortEstimate = strings.TrimSpace(m.effortInput.Value())
+		task.Tags = model.ParseTags(m.tagsInput.Value())
+		task.Project = strings.TrimSpace(m.projectInput.Value())
+		task.Order = engine.NextOrder(m.tasks)
 		m.tasks = append(m.tasks, task)
 	case formEdit:
 		for i := range m.tasks {
 			if m.tasks[i].ID == m.editTaskID {
+				before := m.tasks[i]
 				m.tasks[i].Title = title
+				m.tasks[i].Description = desc
 				m.tasks[i].Important = m.important
 				m.tasks[i].Urgent = m.urgent
 				m.tasks[i].DueAt = due
@@ -420,8 +781,12 @@ func (m Model) submitForm() tea.Model {
 				m.tasks[i].NextAction = strings.TrimSpace(m.nextActionInput.Value())
 				m.tasks[i].PlannedDate = planned
 				m.tasks[i].DelegateTo = strings.TrimSpace(m.delegateInput.Value())
+				m.tasks[i].FollowUpAt = followUp
 				m.tasks[i].DeleteReason = strings.TrimSpace(m.deleteReasonInput.Value())
 				m.tasks[i].EffortEstimate = strings.TrimSpace(m.effortInput.Value())
+				m.tasks[i].Tags = model.ParseTags(m.tagsInput.Value())
+				m.tasks[i].Project = strings.TrimSpace(m.projectInput.Value())
+				m.recordChanges(i, before)
 				break
 			}
 		}
@@ -433,6 +798,7 @@ func (m Model) submitForm() tea.Model {
 }
 
 func (m *Model) saveTasks() {
+	engine.Escalate(m.tasks, time.Now())
 	data, err := store.EncodeTasks(m.tasks)
 	if err != nil {
 		m.setStatusErr("Failed to encode tasks")
@@ -445,22 +811,44 @@ func (m *Model) saveTasks() {
 	m.lastSaveTime = time.Now()
 }
 
+func (m *Model) recordChanges(i int, before model.Task) {
+	m.tasks[i].RecordChanges(before, time.Now())
+}
+
 func (m *Model) setStatusErr(msg string) {
 	m.statusMsg = msg
 	m.statusIsErr = true
 }
 
 func (m Model) visibleIndices() []int {
+	if m.grouped() {
+		return m.groupedIndices()
+	}
 	return m.indicesByQuadrant(m.quadrant)
 }
 
+func (m *Model) nextSection(delta int) {
+	if m.grouped() {
+		m.jumpGroup(delta)
+		return
+	}
+	m.quadrant = (m.quadrant + 4 + delta) % 4
+	m.selected = 0
+}
+
 func (m Model) indicesByQuadrant(q int) []int {
 	indices := make([]int, 0, len(m.tasks))
+	now := time.Now()
 	for i, t := range m.tasks {
-		if engine.QuadrantIndex(t) == q {
-			indices = append(indices, i)
+		if engine.QuadrantIndex(t) != q {
+			continue
+		}
+		if !m.matches(i, now) {
+			continue
 		}
+		indices = append(indices, i)
 	}
+	engine.SortIndices(m.tasks, indices, m.quadrantSort(q), m.cfg.Points())
 	if q == m.quadrant && m.selected >= len(indices) {
 		m.selected = 0
 	}
@@ -468,13 +856,7 @@ func (m Model) indicesByQuadrant(q int) []int {
 }
 
 func (m Model) viewList() string {
-	quadrants := []string{
-		engine.QuadrantImportantImmediate,
-		engine.QuadrantImportantNotImmediate,
-		engine.QuadrantNotImportantImmediate,
-		engine.QuadrantNotImportantNot,
-	}
-	footer := "[↑/↓ or j/k] Move  [a] Add  [e] Edit  [d] Done  [x] Delete  [tab] Next Quadrant  [shift+tab] Prev  [h] Help  [q] Quit"
+	footer := "[↑/↓ or j/k] Move  [enter] View  [a] Add  [e] Edit  [/] Search  [v] Views  [space/V] Select  [d] Done  [x] Trash  [tab] Next Quadrant  [h] Help (all keys)  [q] Quit"
 
 	screenW := m.width
 	screenH := m.height
@@ -482,6 +864,44 @@ func (m Model) viewList() string {
 		screenW = 80
 		screenH = 24
 	}
+	extra := []string{}
+	if line := m.viewLine(); line != "" {
+		extra = append(extra, line)
+	}
+	if m.searching || m.searchQuery != "" {
+		extra = append(extra, m.searchLine())
+	}
+	if m.hasSelection() {
+		extra = append(extra, m.selectionLine())
+	}
+	if line := m.timerLine(); line != "" {
+		extra = append(extra, line)
+	}
+	if m.statusMsg != "" {
+		extra = append(extra, m.statusLine())
+	}
+
+	grid := m.viewBody(screenW, screenH-1-len(extra))
+	parts := append([]string{grid}, extra...)
+	parts = append(parts, footer)
+	return lipgloss.JoinVertical(lipgloss.Left, parts...)
+}
+
+func (m Model) viewBody(screenW, available int) string {
+	if m.grouped() {
+		return m.viewGroupedList(screenW, available)
+	}
+	return m.viewGrid(screenW, available)
+}
+
+func (m Model) viewGrid(screenW, available int) string {
+	quadrants := []string{
+		engine.QuadrantImportantImmediate,
+		engine.QuadrantImportantNotImmediate,
+		engine.QuadrantNotImportantImmediate,
+		engine.QuadrantNotImportantNot,
+	}
+
 	boxGap := 1
 	boxW := (screenW - boxGap) / 2
 	if boxW < 10 {
@@ -493,14 +913,12 @@ func (m Model) viewList() string {
 			boxW = 1
 		}
 	}
-	footerLines := 1
-	available := screenH - footerLines
 	boxH := available / 2
 	if boxH < 5 {
 		boxH = 5
 	}
-	if 2*boxH+footerLines > screenH {
-		boxH = (screenH - footerLines) / 2
+	if 2*boxH > available {
+		boxH = available / 2
 		if boxH < 3 {
 			boxH = 3
 		}
@@ -511,37 +929,21 @@ func (m Model) viewList() string {
 	selectedBorderStyle := lipgloss.NewStyle()
 	selectedTextStyle := lipgloss.NewStyle()
 
+	terms := m.searchFilter.TextTerms()
 	boxes := make([]string, 4)
 	for q := 0; q < 4; q++ {
 		indices := m.indicesByQuadrant(q)
 		lines := make([]string, 0, len(indices)+1)
 		title := strings.ToUpper(quadrants[q])
+		if by := m.quadrantSort(q); by != engine.SortManual {
+			title += " · " + by
+		}
 		borderColor, textColor := quadrantColors(q)
 		if len(indices) == 0 {
 			lines = append(lines, "(no tasks)")
 		} else {
 			for i, idx := range indices {
-				task := m.tasks[idx]
-				cursor := " "
-				if q == m.quadrant && i == m.selected {
-					cursor = ">"
-				}
-				statusMark := "[ ]"
-				switch task.Status {
-				case model.StatusDone:
-					statusMark = "[x]"
-				case model.StatusDeferred:
-					statusMark = "[-]"
-				}
-				due := ""
-				if task.DueAt != nil {
-					due = " (due " + task.DueAt.Format("2006-01-02 15:04") + ")"
-				}
-				statusStyle := lipgloss.NewStyle().Foreground(borderColor)
-				prefix := fmt.Sprintf("%s %s", cursor, statusStyle.Render(statusMark))
-				text := fmt.Sprintf("%s%s", task.Title, due)
-				wrapped := wrapTaskLine(prefix, text, boxW-2)
-				lines = append(lines, wrapped...)
+				lines = append(lines, m.taskLines(idx, q == m.quadrant && i == m.selected, terms, boxW-2)...)
 			}
 		}
 		content := strings.Join(lines, "\n")
@@ -575,21 +977,65 @@ func (m Model) viewList() string {
 
 	topRow := lipgloss.JoinHorizontal(lipgloss.Top, boxes[0], strings.Repeat(" ", boxGap), boxes[1])
 	bottomRow := lipgloss.JoinHorizontal(lipgloss.Top, boxes[2], strings.Repeat(" ", boxGap), boxes[3])
-	grid := lipgloss.JoinVertical(lipgloss.Left, topRow, bottomRow)
-	return lipgloss.JoinVertical(lipgloss.Left, grid, footer)
+	return lipgloss.JoinVertical(lipgloss.Left, topRow, bottomRow)
 }
 
-func quadrantColors(q int) (lipgloss.Color, lipgloss.Color) {
-	switch q {
-	case 0:
-		return lipgloss.Color("196"), lipgloss.Color("255")
-	case 1:
-		return lipgloss.Color("34"), lipgloss.Color("255")
-	case 2:
-		return lipgloss.Color("220"), lipgloss.Color("255")
-	default:
-		return lipgloss.Color("27"), lipgloss.Color("255")
+func (m Model) statusLine() string {
+	color := lipgloss.Color("34")
+	if m.statusIsErr {
+		color = lipgloss.Color("196")
+	}
+	return lipgloss.NewStyle().Foreground(color).Render(m.statusMsg)
+}
+
+// taskLines adds a snippet line when search matched a field other than the title.
+func (m Model) taskLines(idx int, selected bool, terms []string, width int) []string {
+	task := m.tasks[idx]
+	borderColor, _ := quadrantColors(engine.QuadrantIndex(task))
+	cursor := " "
+	if selected {
+		cursor = ">"
 	}
+	mark := " "
+	if m.isMarked(idx) {
+		mark = markStyle.Render("*")
+	}
+	statusMark := "[ ]"
+	switch task.Status {
+	case model.StatusDone:
+		statusMark = "[x]"
+	case model.StatusDeferred:
+		statusMark = "[-]"
+	}
+	due := ""
+	if task.DueAt != nil {
+		due = " (due " + task.DueAt.Format("2006-01-02 15:04") + ")"
+	}
+	tags := ""
+	if len(task.Tags) > 0 {
+		tags = " " + formatTags(task.Tags)
+	}
+	statusStyle := lipgloss.NewStyle().Foreground(borderColor)
+	prefix := fmt.Sprintf("%s%s%s", cursor, mark, statusStyle.Render(statusMark))
+	hit := searchHighlights(task, terms)
+	title := highlight(task.Title, hit.titlePositions)
+	if task.Pinned {
+		title = pinnedStyle.Render("★ ") + pinnedStyle.Render(title)
+	}
+	text := fmt.Sprintf("%s%s%s", title, due, tags)
+	lines := wrapTaskLine(prefix, text, width)
+	if hit.field != "" {
+		indent := strings.Repeat(" ", ansi.PrintableRuneWidth(prefix)+1)
+		label := hit.field + ": "
+		excerpt, positions := snippet(hit.text, hit.positions, width-len(indent)-len(label))
+		dim := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+		lines = append(lines, fitLine(indent+dim.Render(label)+highlight(excerpt, positions), width))
+	}
+	return lines
+}
+
+func quadrantColors(q int) (lipgloss.Color, lipgloss.Color) {
+	return format.QuadrantColor(q), lipgloss.Color("255")
 }
 
 func renderPanelBox(border lipgloss.Border, borderStyle, textStyle lipgloss.Style, width, height int, title, content string) string {
@@ -681,19 +1127,19 @@ func (m Model) formLine(field formField, label, value string) string {
 func (m Model) formFields() []formField {
 	switch {
 	case m.important && m.urgent:
-		return []formField{fieldStatus, fieldTitle, fieldImportant, fieldUrgent, fieldDue, fieldImpact, fieldNextAction}
+		return []formField{fieldStatus, fieldTitle, fieldDescription, fieldTags, fieldProject, fieldImportant, fieldUrgent, fieldDue, fieldImpact, fieldNextAction}
 	case m.important:
-		return []formField{fieldStatus, fieldTitle, fieldImportant, fieldUrgent, fieldPlanned, fieldEffort}
+		return []formField{fieldStatus, fieldTitle, fieldDescription, fieldTags, fieldProject, fieldImportant, fieldUrgent, fieldPlanned, fieldEffort}
 	case m.urgent:
-		return []formField{fieldStatus, fieldTitle, fieldImportant, fieldUrgent, fieldDue, fieldDelegate}
+		return []formField{fieldStatus, fieldTitle, fieldDescription, fieldTags, fieldProject, fieldImportant, fieldUrgent, fieldDue, fieldDelegate, fieldFollowUp}
 	default:
-		return []formField{fieldTitle, fieldImportant, fieldUrgent, fieldDeleteReason}
+		return []formField{fieldTitle, fieldDescription, fieldTags, fieldProject, fieldImportant, fieldUrgent, fieldDeleteReason}
 	}
 }
 
 func (m Model) isTextField(field formField) bool {
 	switch field {
-	case fieldTitle, fieldImpact, fieldNextAction, fieldDelegate, fieldDeleteReason, fieldEffort:
+	case fieldTitle, fieldDescription, fieldImpact, fieldNextAction, fieldDelegate, fieldDeleteReason, fieldEffort, fieldTags, fieldProject:
 		return true
 	default:
 		return false
@@ -727,24 +1173,32 @@ func (m Model) formFieldLines(field formField, maxWidth int) []string {
 		return []string{m.formLine(fieldStatus, "Status", m.statusDisplay())}
 	case fieldTitle:
 		return m.textFieldLines(fieldTitle, "Title", &m.titleInput, maxWidth)
+	case fieldDescription:
+		return m.textAreaLines(fieldDescription, "Description", maxWidth)
 	case fieldImportant:
 		return []string{m.formLine(fieldImportant, "Important", checkbox(m.important))}
 	case fieldUrgent:
 		return []string{m.formLine(fieldUrgent, "Urgent", checkbox(m.urgent))}
 	case fieldDue:
-		return []string{m.formLine(fieldDue, "Due/SLA", m.duePicker.String())}
+		return m.dateFieldLines(fieldDue, "Due/SLA", m.duePicker, maxWidth)
 	case fieldImpact:
 		return m.textFieldLines(fieldImpact, "Impact", &m.impactInput, maxWidth)
 	case fieldNextAction:
 		return m.textFieldLines(fieldNextAction, "Next Action", &m.nextActionInput, maxWidth)
 	case fieldPlanned:
-		return []string{m.formLine(fieldPlanned, "Planned Date", m.plannedPicker.String())}
+		return m.dateFieldLines(fieldPlanned, "Planned Date", m.plannedPicker, maxWidth)
 	case fieldEffort:
 		return m.textFieldLines(fieldEffort, "Effort", &m.effortInput, maxWidth)
 	case fieldDelegate:
 		return m.textFieldLines(fieldDelegate, "Delegate To", &m.delegateInput, maxWidth)
+	case fieldFollowUp:
+		return m.dateFieldLines(fieldFollowUp, "Follow Up", m.followUpPicker, maxWidth)
 	case fieldDeleteReason:
 		return m.textFieldLines(fieldDeleteReason, "Delete Reason", &m.deleteReasonInput, maxWidth)
+	case fieldTags:
+		return m.textFieldLines(fieldTags, "Tags", &m.tagsInput, maxWidth)
+	case fieldProject:
+		return m.textFieldLines(fieldProject, "Project", &m.projectInput, maxWidth)
 	default:
 		return []string{""}
 	}
@@ -811,6 +1265,43 @@ func (m Model) textFieldLines(field formField, label string, input *textinput.Mo
 	return lines
 }
 
+func (m Model) textAreaLines(field formField, label string, maxWidth int) []string {
+	current := m.currentField()
+	isEditing := current != nil && *current == field && m.formEditing
+
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("220"))
+	cursor := " "
+	if current != nil && *current == field {
+		cursor = ">"
+	}
+	prefix := fmt.Sprintf("%s %s: ", cursor, labelStyle.Render(label))
+	if maxWidth <= 0 {
+		maxWidth = 10
+	}
+	indent := "    "
+	available := maxWidth - len(indent)
+	if available < 4 {
+		return []string{fitLine(prefix+m.descriptionInput.Value(), maxWidth)}
+	}
+
+	lines := []string{fitLine(prefix, maxWidth)}
+	if isEditing {
+		for _, line := range strings.Split(m.descriptionInput.View(), "\n") {
+			lines = append(lines, fitLine(indent+line, maxWidth))
+		}
+		return lines
+	}
+
+	value := m.descriptionInput.Value()
+	if value == "" {
+		return lines
+	}
+	for _, segment := range wrapParagraphs(value, available) {
+		lines = append(lines, fitLine(indent+segment, maxWidth))
+	}
+	return lines
+}
+
 func (m Model) statusOrDefault() string {
 	if m.status == "" {
 		return model.StatusPending
@@ -845,6 +1336,8 @@ func (m Model) allInputs() []*textinput.Model {
 		&m.delegateInput,
 		&m.deleteReasonInput,
 		&m.effortInput,
+		&m.tagsInput,
+		&m.projectInput,
 	}
 }
 
@@ -862,11 +1355,64 @@ func (m *Model) inputFor(field formField) *textinput.Model {
 		return &m.deleteReasonInput
 	case fieldEffort:
 		return &m.effortInput
+	case fieldTags:
+		return &m.tagsInput
+	case fieldProject:
+		return &m.projectInput
 	default:
 		return nil
 	}
 }
 
+// pickerFor returns the date picker behind a date field, or nil.
+func (m *Model) pickerFor(field formField) *duePicker {
+	switch field {
+	case fieldDue:
+		return &m.duePicker
+	case fieldPlanned:
+		return &m.plannedPicker
+	case fieldFollowUp:
+		return &m.followUpPicker
+	default:
+		return nil
+	}
+}
+
+// applyDateInput keeps the input open when the date does not parse.
+func (m *Model) applyDateInput(p *duePicker) {
+	value := strings.TrimSpace(m.dateInput.Value())
+	if value != "" {
+		t, err := dateparse.Parse(value, time.Now())
+		if err != nil {
+			m.setStatusErr(err.Error())
+			return
+		}
+		p.enabled = true
+		p.t = t.Truncate(time.Minute)
+	}
+	m.formEditing = false
+	m.statusMsg = ""
+	m.statusIsErr = false
+	m.dateInput.Blur()
+}
+
+func (m Model) dateFieldLines(field formField, label string, p duePicker, maxWidth int) []string {
+	if current := m.currentField(); current == nil || *current != field || !m.formEditing {
+		return []string{m.formLine(field, label, p.String())}
+	}
+	lines := m.textFieldLines(field, label, &m.dateInput, maxWidth)
+	hint := "type a date, [enter] set, [esc] cancel"
+	if value := strings.TrimSpace(m.dateInput.Value()); value != "" {
+		if t, err := dateparse.Parse(value, time.Now()); err == nil {
+			hint = "→ " + t.Format("Mon 2006-01-02 15:04")
+		} else {
+			hint = "? not a date yet"
+		}
+	}
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+	return append(lines, fitLine(dim.Render("    "+hint), maxWidth))
+}
+
 func newDuePicker(due *time.Time) duePicker {
 	if due == nil {
 		return duePicker{
@@ -955,7 +1501,27 @@ func (p *duePicker) adjust(delta int) {
 func (m Model) viewOverlayForm() string {
 	base := m.viewList()
 	modal := m.viewModalBox()
-	return overlayCenter(base, modal, m.width, m.height)
+	view := overlayCenter(base, modal, m.width, m.height)
+	if m.calendar.open {
+		return m.viewCalendar(view)
+	}
+	return view
+}
+
+// formWidth is the width of the add/edit form box.
+func (m Model) formWidth() int {
+	width := m.width
+	if width == 0 || m.height == 0 {
+		width = 80
+	}
+	return min(max(width*2/3, 50), width-4)
+}
+
+// sizeDescription matches the width textAreaLines lays out.
+func (m *Model) sizeDescription() {
+	if available := m.formWidth() - 2 - 4; available >= 4 {
+		m.descriptionInput.SetWidth(available)
+	}
 }
 
 func (m Model) viewModalBox() string {
@@ -964,19 +1530,11 @@ func (m Model) viewModalBox() string {
 		title = "Edit Task"
 	}
 
-	width := m.width
 	height := m.height
-	if width == 0 || height == 0 {
-		width = 80
+	if m.width == 0 || height == 0 {
 		height = 24
 	}
-	boxW := width * 2 / 3
-	if boxW < 50 {
-		boxW = 50
-	}
-	if boxW > width-4 {
-		boxW = width - 4
-	}
+	boxW := m.formWidth()
 	boxH := height/2 + 1
 	if boxH < 10 {
 		boxH = 10
@@ -990,7 +1548,7 @@ func (m Model) viewModalBox() string {
 	}
 	lines = append(lines, "[enter] Next  [esc] Cancel")
 	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("255"))
-	hintText := "[↑/↓, j/k]: move fields  [i]: insert  [enter]: next/save  [esc]: exit/close  [space]: toggle  date: h/l segment  +/- change  t current time  x clear"
+	hintText := "[↑/↓, j/k]: move fields  [i]: insert  [enter]: next/save  [esc]: exit/close  [space]: toggle  date: [i] type  [c] calendar  h/l segment  +/- change  t current time  x clear"
 	hintLines := []string{hintText}
 	topPaddingLines := 1
 	innerHeight := boxH - 2 - topPaddingLines
@@ -1191,19 +1749,71 @@ func (m Model) helpLines(width int) []string {
 		"Navigation",
 		"- [↑/↓] or k/j: move within a quadrant",
 		"- [tab]: switch quadrant",
-		"- [a]: add task, [e]: edit task, [d]: mark done, [x]: delete, [q]: quit",
+		"- [enter]: view all task fields, quadrant, age, time to due and history",
+		"  (j/k move between tasks; shown beside the grid on wide terminals)",
+		"- [E]: open the task in $EDITOR as a front-matter document",
+		"- [/]: search all quadrants (title, description, impact, next action, delegate, tags);",
+		"  [enter] keeps the filter, [n/N] jump between matches, [esc] clears it",
+		"  Filters can be mixed with text: quadrant:iim status:pending due<48h tag:ops",
+		"  delegate:alice created>7d, combined with 'or', '-' (not) and parentheses",
+		"- [v]: pick a saved view (filter + grouping by quadrant, project or delegate",
+		"  + sort order); views are stored in ~/.actnow/config.json",
+		"- [s]: cycle the sort order of the current quadrant (manual, due, planned,",
+		"  created, effort, title); remembered per quadrant",
+		"- [J/K]: move the task down/up within its quadrant (manual sort only)",
+		"- [1-4]: move the task to I+I, I+NI, NI+I or NI+NI; [I]/[U] toggle",
+		"  importance/urgency. The status line lists quadrant fields still empty",
+		"- [p]: pin the task as \"the one thing\"; it always shows first, starred",
+		"- [space]: mark/unmark the task; [V]: start a range, move, [V] again to mark it",
+		"  With tasks marked, d/f/x/t/D/1-4/I/U apply to all of them; [esc] clears",
+		"- [a]: add task, [e]: edit task, [d]: toggle done, [f]: toggle deferred",
+		"- [A]: quick add on one line: Fix outage !imp !urg @due:+2h #ops >alice ~4h",
+		"- [t]: edit tags (+add -remove, or a new list), [D]: set delegate",
+		"- [x]: move to trash (~/.actnow/trash.json) with its delete reason",
+		"- [P]: purge the trash (always asks); [q]: quit",
+		"- [w]: Waiting For: delegated tasks by person with follow-up dates; overdue",
+		"  follow-ups are flagged to escalate. [f] follow-up, [r] replied, [d] done",
+		"- [r]: daily review: walk every pending task, overdue and I+I first, and",
+		"  carry it forward [enter], reclassify [1-4], defer to a date [f],",
+		"  delegate [D], eliminate [x] or mark it done [d]",
+		"- [W]: weekly review: last week's completions by quadrant, then plan I+NI",
+		"  tasks without a planned date on one of the next 7 days [1-7] against the",
+		"  capacity bars; [enter] finishes with a note for the reviews log",
+		"- [g]: agenda: tasks by due and planned date in Overdue, Today, Tomorrow,",
+		"  This week and Later, with a dot in the quadrant's color",
+		"- [F]: focus mode: only the top I+I task, full screen, with its Impact, Next",
+		"  Action and SLA countdown; done [d], skip [s], defer [f], timer [t]",
+		"- [T]: start or stop the timer on the selected task; starting one stops any",
+		"  other. Time spent is shown against the effort estimate in the details",
+		"- [O]: start or stop a pomodoro on the selected task: its timer runs for",
+		"  the work length, then a break; the bell rings at the end of each",
+		"- [S]: statistics: tasks per quadrant, weekly completion rates, lead time,",
+		"  escalations into I+I, overdue counts and a sparkline of completions;",
+		"  [tab] switches between 7, 30 and 90 days",
+		"- [c]: capacity planning: effort of I+NI tasks per planned day for the next",
+		"  two weeks against the daily capacity; overbooked days are flagged",
+		"  Effort: 30m, 2h, 1d (8h), 1h30m, 1w (5d) or story points like 3sp",
+		"- [X]: review NI+NI tasks and eliminate them one by one with their delete",
+		"  reason; [tab] there shows the report of eliminated work",
+		"Deleting, bulk actions and discarding form changes ask for confirmation",
+		"unless \"skip_confirm\": true is set in ~/.actnow/config.json",
 		"",
 		"Quadrants",
 		"- I+I (Important & Immediate): status, title, due/SLA, impact, next action",
 		"- I+NI (Important & Not Immediate): status, title, planned date, effort",
-		"- NI+I (Not Important & Immediate): status, title, due/SLA, delegate to",
+		"- NI+I (Not Important & Immediate): status, title, due/SLA, delegate to, follow up",
 		"- NI+NI (Not Important & Not Immediate): title, delete reason",
 		"",
 		"Form editing",
 		"- [↑/↓] or j/k: move fields, [i] insert, [enter] next/save",
 		"- [esc] exit insert or close the form",
+		"- Description: [i] to edit notes; [enter] adds a new line, [esc] finishes",
 		"- [space]: toggle checkboxes",
-		"- Date fields: [h/l] move segment, [j/k] change value, [t] now, [x] clear",
+		"- Date fields: [i] type a date (tomorrow 9:00, fri, +3d, in 2h, next monday,",
+		"  eod, eow, 2024-03-08), [c] calendar, [h/l] move segment, [+/-] change value,",
+		"  [t] now, [x] clear",
+		"- Calendar: [hjkl] day/week, [ and ] month, [.] today, [i] time, [+/-] 15",
+		"  minutes, [enter] set; dots mark days with tasks due (red) or planned (blue)",
 		"",
 		"Examples",
 		"1) I+I incident",
@@ -1405,6 +2015,18 @@ func wrapTaskLine(prefix, text string, maxWidth int) []string {
 	return lines
 }
 
+func wrapParagraphs(s string, width int) []string {
+	out := []string{}
+	for _, paragraph := range strings.Split(s, "\n") {
+		if strings.TrimSpace(paragraph) == "" {
+			out = append(out, "")
+			continue
+		}
+		out = append(out, wrapLineHard(paragraph, width)...)
+	}
+	return out
+}
+
 func flattenWrapped(lines []string) []string {
 	out := make([]string, 0, len(lines))
 	for _, line := range lines {
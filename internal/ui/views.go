@@ -0,0 +1,250 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/mrbooshehri/actNow/internal/engine"
+	"github.com/mrbooshehri/actNow/internal/query"
+)
+
+// applyView with a negative i restores the plain grid.
+func (m *Model) applyView(i int) {
+	m.activeView = -1
+	m.viewFilter = nil
+	m.selected = 0
+	if m.cfg == nil || i < 0 || i >= len(m.cfg.Views) {
+		return
+	}
+	q, err := query.Parse(m.cfg.Views[i].Filter)
+	if err != nil {
+		m.setStatusErr("View filter: " + err.Error())
+		return
+	}
+	m.activeView = i
+	m.viewFilter = q
+	m.selectFirstHit()
+}
+
+func (m Model) viewGroup() string {
+	if m.cfg == nil || m.activeView < 0 {
+		return engine.GroupQuadrant
+	}
+	if g := m.cfg.Views[m.activeView].Group; g != "" {
+		return g
+	}
+	return engine.GroupQuadrant
+}
+
+// quadrantSort prefers the active view's sort.
+func (m Model) quadrantSort(q int) string {
+	if by := m.viewSort(); by != "" {
+		return by
+	}
+	return m.cfg.SortFor(q)
+}
+
+func (m Model) viewSort() string {
+	if m.cfg == nil || m.activeView < 0 {
+		return ""
+	}
+	return m.cfg.Views[m.activeView].Sort
+}
+
+func (m *Model) cycleSort() {
+	if m.cfg == nil {
+		return
+	}
+	if m.grouped() || m.viewSort() != "" {
+		m.SetStatus("Sort order is set by the active view", true)
+		return
+	}
+	by := engine.NextSort(m.cfg.SortFor(m.quadrant))
+	m.cfg.SetSort(m.quadrant, by)
+	m.selected = 0
+	if err := m.cfg.SaveSort(m.quadrant); err != nil {
+		m.setStatusErr("Failed to save config: " + err.Error())
+		return
+	}
+	m.SetStatus("Sorted by "+by, false)
+}
+
+func (m Model) grouped() bool {
+	return m.viewGroup() != engine.GroupQuadrant
+}
+
+func (m Model) matches(i int, now time.Time) bool {
+	t := m.tasks[i]
+	return m.viewFilter.Match(t, now) && m.searchFilter.Match(t, now)
+}
+
+func (m Model) taskGroups() []engine.Group {
+	now := time.Now()
+	indices := make([]int, 0, len(m.tasks))
+	for i := range m.tasks {
+		if m.matches(i, now) {
+			indices = append(indices, i)
+		}
+	}
+	engine.SortIndices(m.tasks, indices, m.viewSort(), m.cfg.Points())
+	return engine.GroupTasks(m.tasks, indices, m.viewGroup())
+}
+
+func (m Model) groupedIndices() []int {
+	var out []int
+	for _, g := range m.taskGroups() {
+		out = append(out, g.Indices...)
+	}
+	return out
+}
+
+func (m *Model) jumpGroup(delta int) {
+	groups := m.taskGroups()
+	if len(groups) == 0 {
+		return
+	}
+	starts := make([]int, len(groups))
+	current := 0
+	pos := 0
+	for i, g := range groups {
+		starts[i] = pos
+		if m.selected >= pos && m.selected < pos+len(g.Indices) {
+			current = i
+		}
+		pos += len(g.Indices)
+	}
+	next := (current + delta + len(groups)) % len(groups)
+	m.selected = starts[next]
+}
+
+func (m Model) updateViewPicker(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	count := 1
+	if m.cfg != nil {
+		count += len(m.cfg.Views)
+	}
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc", "q", "v":
+		m.mode = modeList
+	case "up", "k":
+		m.pickerIndex = (m.pickerIndex + count - 1) % count
+	case "down", "j":
+		m.pickerIndex = (m.pickerIndex + 1) % count
+	case "enter":
+		m.applyView(m.pickerIndex - 1)
+		m.mode = modeList
+	}
+	return m, nil
+}
+
+func (m Model) viewPickerOverlay() string {
+	base := m.viewList()
+	lines := []string{}
+	names := []string{"All tasks (2x2 grid)"}
+	details := []string{"no filter"}
+	if m.cfg != nil {
+		for _, v := range m.cfg.Views {
+			names = append(names, v.Name)
+			detail := v.Filter
+			if v.Group != "" && v.Group != engine.GroupQuadrant {
+				detail += "  by " + v.Group
+			}
+			if v.Sort != "" {
+				detail += "  sort " + v.Sort
+			}
+			details = append(details, detail)
+		}
+	}
+
+	boxW := 60
+	if m.width > 0 && boxW > m.width-4 {
+		boxW = m.width - 4
+	}
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+	for i, name := range names {
+		cursor := " "
+		if i == m.pickerIndex {
+			cursor = ">"
+		}
+		active := " "
+		if i-1 == m.activeView {
+			active = "*"
+		}
+		lines = append(lines, fitLine(fmt.Sprintf("%s%s %s", cursor, active, name), boxW-4))
+		lines = append(lines, fitLine("    "+dim.Render(details[i]), boxW-4))
+	}
+	lines = append(lines, "")
+	lines = append(lines, dim.Render("[j/k] move  [enter] open  [esc] cancel"))
+
+	border := lipgloss.NormalBorder()
+	borderStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+	textStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("255"))
+	content := padModalContent(strings.Join(lines, "\n"), 1, boxW-2)
+	modal := renderPanelBox(border, borderStyle, textStyle, boxW, len(lines)+2, "Views", content)
+	return overlayCenter(base, modal, m.width, m.height)
+}
+
+func (m Model) viewGroupedList(screenW, available int) string {
+	view := m.cfg.Views[m.activeView]
+	boxW := screenW
+	boxH := available
+	if boxH < 3 {
+		boxH = 3
+	}
+	terms := m.searchFilter.TextTerms()
+	headingStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Bold(true)
+
+	lines := []string{}
+	selectedLine := 0
+	pos := 0
+	for _, g := range m.taskGroups() {
+		if len(g.Indices) == 0 {
+			continue
+		}
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, headingStyle.Render(fmt.Sprintf("%s (%d)", g.Name, len(g.Indices))))
+		for _, idx := range g.Indices {
+			if pos == m.selected {
+				selectedLine = len(lines)
+			}
+			lines = append(lines, m.taskLines(idx, pos == m.selected, terms, boxW-2)...)
+			pos++
+		}
+	}
+	if len(lines) == 0 {
+		lines = append(lines, "(no tasks)")
+	}
+
+	maxLines := boxH - 2
+	if maxLines < 1 {
+		maxLines = 1
+	}
+	start := 0
+	if len(lines) > maxLines {
+		start = clamp(selectedLine-maxLines/2, 0, len(lines)-maxLines)
+		lines = lines[start : start+maxLines]
+	}
+
+	border := lipgloss.ThickBorder()
+	borderStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+	textStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("255"))
+	title := strings.ToUpper(view.Name) + " — BY " + strings.ToUpper(view.Group)
+	return renderPanelBox(border, borderStyle, textStyle, boxW, boxH, title, strings.Join(lines, "\n"))
+}
+
+func (m Model) viewLine() string {
+	if m.cfg == nil || m.activeView < 0 {
+		return ""
+	}
+	v := m.cfg.Views[m.activeView]
+	style := lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+	return style.Render("View: "+v.Name) + dim.Render("  "+v.Filter+"  [v] switch")
+}
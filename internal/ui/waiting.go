@@ -0,0 +1,201 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/mrbooshehri/actNow/internal/dateparse"
+	"github.com/mrbooshehri/actNow/internal/engine"
+	"github.com/mrbooshehri/actNow/internal/format"
+	"github.com/mrbooshehri/actNow/internal/model"
+)
+
+var escalateStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Bold(true)
+
+func (m Model) waitingIndices() []int {
+	var out []int
+	for _, g := range engine.WaitingFor(m.tasks, "") {
+		out = append(out, g.Indices...)
+	}
+	return out
+}
+
+func (m Model) startWaiting() (tea.Model, tea.Cmd) {
+	m.mode = modeWaiting
+	m.waitingIndex = 0
+	return m, nil
+}
+
+func (m Model) updateWaiting(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.statusMsg = ""
+	m.statusIsErr = false
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc", "q", "w":
+		m.mode = modeList
+		return m, nil
+	}
+	indices := m.waitingIndices()
+	if len(indices) == 0 {
+		return m, nil
+	}
+	m.waitingIndex = clamp(m.waitingIndex, 0, len(indices)-1)
+	idx := indices[m.waitingIndex]
+	id := m.tasks[idx].ID
+
+	switch msg.String() {
+	case "up", "k":
+		m.waitingIndex = max(m.waitingIndex-1, 0)
+	case "down", "j":
+		m.waitingIndex = min(m.waitingIndex+1, len(indices)-1)
+	case "tab":
+		m.waitingIndex = m.nextWaitingGroup()
+	case "f":
+		value := ""
+		if t := m.tasks[idx].FollowUpAt; t != nil {
+			value = t.Format("2006-01-02")
+		}
+		return m, m.prompt("Follow up", "Follow up with "+m.tasks[idx].DelegateTo+" on (e.g. fri, +2d, 2024-03-08; empty clears):", value, func(m *Model, value string) {
+			at, err := parseOptionalDate(value, time.Now())
+			if err != nil {
+				m.setStatusErr("Follow-up: " + err.Error())
+				return
+			}
+			m.updateTaskByID(id, "Follow-up set", func(t *model.Task) { t.FollowUpAt = at })
+		})
+	case "r":
+		m.updateTaskByID(id, "Reply recorded; follow-up cleared", func(t *model.Task) {
+			t.Record(time.Now(), "delegate replied")
+			t.FollowUpAt = nil
+		})
+	case "d":
+		m.updateTaskByID(id, "Marked done", func(t *model.Task) { t.Status = model.StatusDone })
+	case "e":
+		m.startForm(formEdit, m.tasks[idx])
+		return m, m.focusCmd()
+	}
+	return m, nil
+}
+
+func (m *Model) updateTaskByID(id, status string, fn func(t *model.Task)) {
+	for i := range m.tasks {
+		if m.tasks[i].ID != id {
+			continue
+		}
+		before := m.tasks[i]
+		fn(&m.tasks[i])
+		m.recordChanges(i, before)
+		m.saveTasks()
+		if !m.statusIsErr {
+			m.SetStatus(status, false)
+		}
+		return
+	}
+}
+
+func (m Model) nextWaitingGroup() int {
+	pos := 0
+	for _, g := range engine.WaitingFor(m.tasks, "") {
+		pos += len(g.Indices)
+		if pos > m.waitingIndex {
+			if pos >= len(m.waitingIndices()) {
+				return 0
+			}
+			return pos
+		}
+	}
+	return 0
+}
+
+// parseOptionalDate gives nil for an empty string.
+func parseOptionalDate(s string, now time.Time) (*time.Time, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+	at, err := dateparse.Parse(s, now)
+	if err != nil {
+		return nil, err
+	}
+	return &at, nil
+}
+
+func (m Model) waitingLines(width int) ([]string, int) {
+	groups := engine.WaitingFor(m.tasks, "")
+	if len(groups) == 0 {
+		return []string{"Nothing delegated. Set Delegate To on NI+I tasks, or press [D] in the list."}, 0
+	}
+	now := time.Now()
+	headingStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Bold(true)
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+	var lines []string
+	selectedLine := 0
+	pos := 0
+	for _, g := range groups {
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		overdue := 0
+		for _, idx := range g.Indices {
+			if engine.FollowUpOverdue(m.tasks[idx], now) {
+				overdue++
+			}
+		}
+		heading := headingStyle.Render(fmt.Sprintf("%s (%d)", g.Name, len(g.Indices)))
+		if overdue > 0 {
+			heading += "  " + escalateStyle.Render(fmt.Sprintf("%d to chase", overdue))
+		}
+		lines = append(lines, heading)
+		for _, idx := range g.Indices {
+			if pos == m.waitingIndex {
+				selectedLine = len(lines)
+			}
+			lines = append(lines, m.taskLines(idx, pos == m.waitingIndex, nil, width)...)
+			t := m.tasks[idx]
+			switch {
+			case engine.FollowUpOverdue(t, now):
+				lines = append(lines, fitLine("      "+escalateStyle.Render("! follow-up overdue by "+format.Duration(now.Sub(*t.FollowUpAt))+": escalate"), width))
+			case t.FollowUpAt != nil:
+				lines = append(lines, fitLine(dim.Render("      follow up "+formatDue(t.FollowUpAt, now)), width))
+			default:
+				lines = append(lines, fitLine(dim.Render("      no follow-up date"), width))
+			}
+			pos++
+		}
+	}
+	return lines, selectedLine
+}
+
+func (m Model) viewWaiting() string {
+	width, height := m.width, m.height
+	if width == 0 || height == 0 {
+		width, height = 80, 24
+	}
+	footer := "[j/k] move  [tab] next person  [f] follow-up date  [r] replied  [d] done  [e] edit  [esc] back"
+	extra := []string{}
+	if m.statusMsg != "" {
+		extra = append(extra, m.statusLine())
+	}
+	boxH := max(height-1-len(extra), 3)
+
+	lines, selectedLine := m.waitingLines(width - 2)
+	maxLines := boxH - 2
+	if len(lines) > maxLines {
+		start := clamp(selectedLine-maxLines/2, 0, len(lines)-maxLines)
+		lines = lines[start : start+maxLines]
+	}
+
+	borderColor, _ := quadrantColors(2)
+	border := lipgloss.ThickBorder()
+	borderStyle := lipgloss.NewStyle().Foreground(borderColor)
+	textStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("255"))
+	box := renderPanelBox(border, borderStyle, textStyle, width, boxH, "WAITING FOR", strings.Join(lines, "\n"))
+	footerStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+	parts := append([]string{box}, extra...)
+	parts = append(parts, footerStyle.Render(footer))
+	return lipgloss.JoinVertical(lipgloss.Left, parts...)
+}
@@ -0,0 +1,179 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/mrbooshehri/actNow/internal/engine"
+	"github.com/mrbooshehri/actNow/internal/format"
+	"github.com/mrbooshehri/actNow/internal/model"
+)
+
+// weeklyDays is how many days ahead the weekly review plans, from today.
+const weeklyDays = 7
+
+func (m Model) startWeekly() (tea.Model, tea.Cmd) {
+	m.mode = modeWeekly
+	m.weeklyIndex = 0
+	m.weeklyPlanned = 0
+	return m, nil
+}
+
+func (m Model) updateWeekly(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.statusMsg = ""
+	m.statusIsErr = false
+	unplanned := engine.UnplannedImportant(m.tasks)
+	switch key := msg.String(); key {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc", "q":
+		m.mode = modeList
+		m.SetStatus("Weekly review left without a note; it was not recorded", true)
+	case "up", "k":
+		m.weeklyIndex = max(m.weeklyIndex-1, 0)
+	case "down", "j":
+		m.weeklyIndex = min(m.weeklyIndex+1, max(len(unplanned)-1, 0))
+	case "1", "2", "3", "4", "5", "6", "7":
+		if len(unplanned) == 0 {
+			return m, nil
+		}
+		idx := unplanned[clamp(m.weeklyIndex, 0, len(unplanned)-1)]
+		day := engine.StartOfDay(time.Now()).AddDate(0, 0, int(key[0]-'1')).Add(9 * time.Hour)
+		m.updateTaskByID(m.tasks[idx].ID, "Planned "+m.tasks[idx].Title+" for "+day.Format("Mon Jan 2"), func(t *model.Task) {
+			t.PlannedDate = &day
+		})
+		if !m.statusIsErr {
+			m.weeklyPlanned++
+			m.weeklyIndex = clamp(m.weeklyIndex, 0, max(len(unplanned)-2, 0))
+		}
+	case "enter", "n":
+		return m, m.prompt("Weekly review", "Review note (what went well, what to change):", "", func(m *Model, note string) {
+			m.finishWeekly(note)
+		})
+	}
+	return m, nil
+}
+
+func (m *Model) finishWeekly(note string) {
+	reviews, ok := m.loadReviews()
+	if !ok {
+		return
+	}
+	outcomes := map[string]int{}
+	if m.weeklyPlanned > 0 {
+		outcomes["planned"] = m.weeklyPlanned
+	}
+	now := time.Now()
+	for q, n := range engine.CompletedByQuadrant(m.tasks, now.AddDate(0, 0, -7), now) {
+		if n > 0 {
+			outcomes["done "+engine.QuadrantKeys[q]] = n
+		}
+	}
+	review := model.Review{Kind: model.ReviewWeekly, At: now, Tasks: m.weeklyPlanned, Outcomes: outcomes, Note: note}
+	if !m.saveReviews(append(reviews, review)) {
+		return
+	}
+	m.mode = modeList
+	m.SetStatus("Weekly review recorded; "+format.Plural(m.weeklyPlanned, "task", "tasks")+" planned", false)
+}
+
+func (m Model) weeklyLines(width int) ([]string, int) {
+	now := time.Now()
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Bold(true)
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+
+	done := engine.CompletedByQuadrant(m.tasks, now.AddDate(0, 0, -7), now)
+	total := 0
+	parts := make([]string, len(done))
+	for q, n := range done {
+		total += n
+		parts[q] = fmt.Sprintf("%s %d", format.QuadrantLabels[q], n)
+	}
+	lines := []string{
+		labelStyle.Render("Last 7 days: ") + format.Plural(total, "task", "tasks") + " done",
+		fitLine("  "+strings.Join(parts, " · "), width),
+	}
+	if rows := engine.TimeReport(m.tasks, now.AddDate(0, 0, -7), now, m.cfg.Points()); len(rows) > 0 {
+		var spent time.Duration
+		over := 0
+		for _, r := range rows {
+			spent += r.Spent
+			if r.Over() {
+				over++
+			}
+		}
+		lines = append(lines, fitLine(fmt.Sprintf("  %s tracked on %s, %d over estimate", format.Hours(spent), format.Plural(len(rows), "task", "tasks"), over), width))
+	}
+	lines = append(lines,
+		"",
+		labelStyle.Render("Coming week")+dim.Render(" (capacity "+format.Hours(m.cfg.Capacity())+" a day)"),
+	)
+	capacity := m.cfg.Capacity()
+	barW := clamp(width-36, 8, 24)
+	for i, l := range engine.PlanCapacity(m.tasks, now, weeklyDays, m.cfg.Points()) {
+		line := fmt.Sprintf("  [%d] %s  %s  %s/%s", i+1, l.Day.Format("Mon Jan 2"), capacityBar(l.Effort, capacity, barW),
+			format.Hours(l.Effort), format.Hours(capacity))
+		if l.Overbooked(capacity) {
+			line += "  " + overbookedStyle.Render("overbooked")
+		}
+		lines = append(lines, fitLine(line, width))
+	}
+
+	unplanned := engine.UnplannedImportant(m.tasks)
+	lines = append(lines, "", labelStyle.Render(fmt.Sprintf("Important & Not Immediate without a planned date (%d)", len(unplanned))))
+	if len(unplanned) == 0 {
+		lines = append(lines, "  Everything is planned.")
+		return lines, 0
+	}
+	selectedLine := 0
+	for i, idx := range unplanned {
+		selected := i == clamp(m.weeklyIndex, 0, len(unplanned)-1)
+		if selected {
+			selectedLine = len(lines)
+		}
+		effort := m.formatEffort(m.tasks[idx].EffortEstimate)
+		if effort == "" {
+			effort = "no estimate"
+		}
+		for j, line := range m.taskLines(idx, selected, nil, width-len(effort)-2) {
+			if j == 0 {
+				line += dim.Render("  " + effort)
+			}
+			lines = append(lines, line)
+		}
+	}
+	return lines, selectedLine
+}
+
+func (m Model) viewWeekly() string {
+	width, height := m.width, m.height
+	if width == 0 || height == 0 {
+		width, height = 80, 24
+	}
+	footer := "[j/k] move  [1-7] plan on that day  [enter] finish with a note  [esc] leave"
+	extra := []string{}
+	if m.statusMsg != "" {
+		extra = append(extra, m.statusLine())
+	}
+	boxH := max(height-1-len(extra), 3)
+	lines, selectedLine := m.weeklyLines(width - 2)
+	maxLines := boxH - 2
+	if len(lines) > maxLines {
+		start := clamp(selectedLine-maxLines/2, 0, len(lines)-maxLines)
+		lines = lines[start : start+maxLines]
+	}
+
+	borderColor, _ := quadrantColors(1)
+	border := lipgloss.ThickBorder()
+	borderStyle := lipgloss.NewStyle().Foreground(borderColor)
+	textStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("255"))
+	box := renderPanelBox(border, borderStyle, textStyle, width, boxH, "WEEKLY REVIEW", strings.Join(lines, "\n"))
+	footerStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+	parts := append([]string{box}, extra...)
+	parts = append(parts, footerStyle.Render(fitLine(footer, width)))
+	return lipgloss.JoinVertical(lipgloss.Left, parts...)
+}